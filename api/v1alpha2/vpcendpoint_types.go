@@ -26,9 +26,37 @@ type SecurityGroupRule struct {
 	// +kubebuilder:validation:Format=cidr
 
 	// CidrIp is the IPv4 address range, in CIDR format, to allow.
-	// If not specified, the cluster's master and worker security group are allowed instead.
+	// If no CIDRs, source security groups, or prefix lists are specified, the cluster's master and worker security
+	// groups are allowed instead.
 	CidrIp string `json:"cidrIp,omitempty"`
 
+	// +kubebuilder:validation:Format=cidr
+
+	// CidrIpv6 is the IPv6 address range, in CIDR format, to allow.
+	// It can be specified alongside CidrIp to allow both an IPv4 and IPv6 address range.
+	CidrIpv6 string `json:"cidrIpv6,omitempty"`
+
+	// +kubebuilder:validation:items:Format=cidr
+
+	// Cidrs is a list of additional IPv4 or IPv6 address ranges, in CIDR format, to allow.
+	Cidrs []string `json:"cidrs,omitempty"`
+
+	// +kubebuilder:validation:items:Pattern=`^([0-9]{12}/)?sg-[0-9a-f]+$`
+
+	// SourceSecurityGroupIds is a list of security group ids to allow, optionally prefixed by the id of the AWS
+	// account that owns the security group, i.e. "sg-0123456789abcdef0" or "123456789012/sg-0123456789abcdef0".
+	SourceSecurityGroupIds []string `json:"sourceSecurityGroupIds,omitempty"`
+
+	// +kubebuilder:validation:items:Pattern=`^pl-[0-9a-f]+$`
+
+	// PrefixListIds is a list of managed prefix list ids to allow.
+	PrefixListIds []string `json:"prefixListIds,omitempty"`
+
+	// +kubebuilder:validation:MaxLength=255
+
+	// Description is added to each security group rule created for this rule.
+	Description string `json:"description,omitempty"`
+
 	// FromPort and ToPort are the start and end of the port range to allow.
 	// In the case of a single port, set both to the same value.
 	FromPort int32 `json:"fromPort,omitempty"`
@@ -41,8 +69,32 @@ type SecurityGroupRule struct {
 	Protocol string `json:"protocol,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:message=.spec.securityGroup.additionalSecurityGroupIds or .spec.securityGroup.additionalSecurityGroupTags must be specified when .spec.securityGroup.mode is UserProvided,rule=!(has(self.mode) && self.mode == 'UserProvided' && !has(self.additionalSecurityGroupIds) && !has(self.additionalSecurityGroupTags))
+// +kubebuilder:validation:XValidation:message=.spec.securityGroup.ingressRules, .spec.securityGroup.egressRules, and .spec.securityGroup.strict are not supported when .spec.securityGroup.mode is UserProvided,rule=!(has(self.mode) && self.mode == 'UserProvided' && (has(self.ingressRules) || has(self.egressRules) || (has(self.strict) && self.strict)))
+
 // SecurityGroup represents the configuration of a security group associated with the VPC Endpoint created by this CR
 type SecurityGroup struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Enum=Managed;UserProvided
+
+	// Mode is Managed (default) to create and manage a security group for the VPC Endpoint, attached alongside any
+	// additional security groups, or UserProvided to attach only the additional security groups and never create one.
+	// The managed security group, if one was already created, is deleted along with the VpcEndpoint.
+	Mode string `json:"mode,omitempty"`
+
+	// +kubebuilder:validation:items:Pattern=`^sg-[0-9a-f]+$`
+
+	// AdditionalSecurityGroupIds is a list of pre-existing security groups to attach to the VPC Endpoint.
+	// They are never modified by AVO.
+	// +optional
+	AdditionalSecurityGroupIds []string `json:"additionalSecurityGroupIds,omitempty"`
+
+	// AdditionalSecurityGroupTags selects pre-existing security groups in the VPC Endpoint's VPC with all the
+	// specified tags to attach to the VPC Endpoint. They are never modified by AVO.
+	// +optional
+	AdditionalSecurityGroupTags []Tag `json:"additionalSecurityGroupTags,omitempty"`
+
 	// IngressRules is a list of security group ingress rules.
 	// They will be allowed for the master and worker security groups.
 	// +optional
@@ -52,6 +104,12 @@ type SecurityGroup struct {
 	// They will be allowed for the master and worker security groups.
 	// +optional
 	EgressRules []SecurityGroupRule `json:"egressRules,omitempty"`
+
+	// Strict revokes any rule in the security group that is not specified by IngressRules or EgressRules, including
+	// the default egress rule AWS adds to new security groups. Rules managed outside of AVO can be kept by listing their
+	// ids in the "vpcendpoint.avo.openshift.io/unmanaged-security-group-rules" annotation, or "*" to keep all of them.
+	// +optional
+	Strict bool `json:"strict,omitempty"`
 }
 
 // Tag represents a key-value pair to filter AWS resources by
@@ -63,6 +121,81 @@ type Tag struct {
 	Value string `json:"value"`
 }
 
+// DnsOptions represents the DNS options of a VPC Endpoint
+// Ref: https://docs.aws.amazon.com/vpc/latest/privatelink/privatelink-access-aws-services.html#dns-records-ip-address-type
+type DnsOptions struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ipv4;dualstack;ipv6;service-defined
+
+	// DnsRecordIpType is the type of DNS records created for the VPC Endpoint: ipv4 | dualstack | ipv6 | service-defined
+	DnsRecordIpType string `json:"dnsRecordIpType,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// PrivateDnsOnlyForInboundResolverEndpoint indicates whether to enable private DNS only for inbound endpoints.
+	// This option is only available for services that support both gateway and interface endpoints, e.g. S3.
+	PrivateDnsOnlyForInboundResolverEndpoint *bool `json:"privateDnsOnlyForInboundResolverEndpoint,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:message=exactly one of .spec.policy.document or .spec.policy.configMapKeyRef must be specified,rule=has(self.document) != has(self.configMapKeyRef)
+
+// Policy represents the source of a VPC Endpoint policy document
+// Ref: https://docs.aws.amazon.com/vpc/latest/privatelink/vpc-endpoints-access.html
+type Policy struct {
+	// +kubebuilder:validation:Optional
+
+	// Document is an inline JSON policy document
+	Document string `json:"document,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// ConfigMapKeyRef selects a key of a ConfigMap in the same namespace as the VpcEndpoint containing a JSON policy
+	// document. Changes to the ConfigMap are picked up the next time the VpcEndpoint is reconciled.
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// RecreateBackoff configures the exponential backoff between re-creations of a VPC Endpoint
+type RecreateBackoff struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+
+	// MaxAttempts is the maximum number of times the VPC Endpoint is recreated before giving up (defaults to 5).
+	// The attempts are reset once the VPC Endpoint becomes available.
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1m"
+
+	// InitialDelay is the delay between the first and second re-creation, doubling for every following attempt
+	// (defaults to 1m). The first re-creation is not delayed.
+	InitialDelay metav1.Duration `json:"initialDelay,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1h"
+
+	// MaxDelay is the maximum delay between re-creations (defaults to 1h)
+	MaxDelay metav1.Duration `json:"maxDelay,omitempty"`
+}
+
+// SubnetConfiguration is the static private IP addresses of a VPC Endpoint in one of its subnets
+type SubnetConfiguration struct {
+	// +kubebuilder:validation:Required
+
+	// SubnetId is the id of one of the VPC Endpoint's subnets
+	SubnetId string `json:"subnetId"`
+
+	// +kubebuilder:validation:Optional
+
+	// Ipv4 is the IPv4 address of the VPC Endpoint's network interface in the subnet
+	Ipv4 string `json:"ipv4,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Ipv6 is the IPv6 address of the VPC Endpoint's network interface in the subnet
+	Ipv6 string `json:"ipv6,omitempty"`
+}
+
 // Vpc represents the configuration for the AWS VPC to create the VPC Endpoint in
 type Vpc struct {
 	// +kubebuilder:validation:Optional
@@ -72,6 +205,16 @@ type Vpc struct {
 	// tag-key "kubernetes.io/role/internal-elb" will be used instead.
 	AutoDiscoverSubnets bool `json:"autoDiscoverSubnets,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Tags
+	// +kubebuilder:validation:Enum=Tags;RouteTables
+
+	// SubnetDiscoveryMode is how .spec.vpc.autoDiscoverSubnets finds private subnets: Tags | RouteTables (defaults to
+	// Tags). Tags uses the "kubernetes.io/role/internal-elb" tag-key, falling back on every subnet with the cluster's
+	// tag-key, public ones included, for BYOVPC clusters. RouteTables excludes subnets whose route table, either
+	// explicitly associated or the VPC's main route table, has a default route to an internet gateway.
+	SubnetDiscoveryMode string `json:"subnetDiscoveryMode,omitempty"`
+
 	// +kubebuilder:validation:Optional
 
 	// SubnetIds is a list of subnet ids to associate with the VPC Endpoint, which must all be in the same VPC.
@@ -81,10 +224,50 @@ type Vpc struct {
 
 	// +kubebuilder:validation:Optional
 
-	// Ids is a list of VPC ids that aws-vpce-operator can choose from to load balance in a "least used"
-	// fashion to evenly spread quota usage across provided VPCs. All provided VPCs must be in the
-	// same region as the specified VPC Endpoint Service (.spec.serviceName) and must use subnet auto-discovery
-	// (.spec.vpc.autoDiscoverSubnets true) based on the "kubernetes.io/role/internal-elb" tag key
+	// SubnetConfigurations assigns static private IP addresses to the VPC Endpoint in some or all of its subnets, e.g.
+	// for firewall allowlists. Each address must be within the CIDR block of its subnet, and changing an address
+	// replaces the VPC Endpoint's network interface in that subnet. Not supported for Gateway VPC Endpoints.
+	SubnetConfigurations []SubnetConfiguration `json:"subnetConfigurations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=LowestId
+	// +kubebuilder:validation:Enum=LowestId;MostFreeIps;PreferredTag
+
+	// SubnetSelectionPolicy is how one subnet is selected per Availability Zone when auto-discovery finds several
+	// private subnets in the same Availability Zone: LowestId | MostFreeIps | PreferredTag (defaults to LowestId).
+	// MostFreeIps keeps the subnet already selected for an Availability Zone as long as it is still discovered, and
+	// PreferredTag selects subnets with .spec.vpc.preferredSubnetTag, falling back on the lowest subnet id.
+	SubnetSelectionPolicy string `json:"subnetSelectionPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// PreferredSubnetTag is the AWS tag key-value pair, or only key if the value is empty, of the subnets to prefer
+	// when .spec.vpc.subnetSelectionPolicy is PreferredTag
+	PreferredSubnetTag *Tag `json:"preferredSubnetTag,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+
+	// MinAvailabilityZones is the minimum number of Availability Zones the VPC Endpoint must have subnets in. When
+	// coverage is below it, the AZCoverage and AWSVpcEndpointReady conditions are False with reason Degraded.
+	MinAvailabilityZones int32 `json:"minAvailabilityZones,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+
+	// MinFreeIpAddresses is the number of free IP addresses a subnet must have for the VPC Endpoint to be added to it
+	// (defaults to 1), since the VPC Endpoint's network interface consumes one. Auto-discovered subnets below it are
+	// only selected when there is no other subnet in their Availability Zone, and the SubnetCapacity condition lists
+	// the subnets the VPC Endpoint was not added to.
+	MinFreeIpAddresses int32 `json:"minFreeIpAddresses,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Ids is a list of VPC ids that aws-vpce-operator can choose from to load balance according to
+	// .spec.vpc.placementStrategy, "least used" by default, to evenly spread quota usage across provided VPCs.
+	// All provided VPCs must be in the same region as the specified VPC Endpoint Service (.spec.serviceName) and must
+	// use subnet auto-discovery (.spec.vpc.autoDiscoverSubnets true) based on the "kubernetes.io/role/internal-elb" tag key
 	Ids []string `json:"ids,omitempty"`
 
 	// +kubebuilder:validation:Optional
@@ -98,6 +281,92 @@ type Vpc struct {
 	// SubnetTags is a list of AWS tag key-value pairs to additionally filter private-subnets with. The main tags used
 	// when filtering subnets is controlled by .spec.vpc.autoDiscoverSubnets
 	SubnetTags []Tag `json:"subnetTags,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// RouteTableIds is a list of route table ids to associate with a Gateway VPC Endpoint, which must all be in the
+	// same VPC. If neither .spec.vpc.routeTableIds nor .spec.vpc.routeTableTags are specified, all route tables in
+	// the VPC are associated. Only used when .spec.type is Gateway.
+	RouteTableIds []string `json:"routeTableIds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// RouteTableTags is a list of AWS tag key-value pairs to filter the route tables in the VPC to associate with a
+	// Gateway VPC Endpoint. If the VPC isn't otherwise selected by .spec.vpc, it is selected among the VPCs of the
+	// route tables with these tags. Only used when .spec.type is Gateway.
+	RouteTableTags []Tag `json:"routeTableTags,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Rebalance opts into periodically moving the VPC Endpoint from an over-used VPC to an under-used one among
+	// .spec.vpc.ids or the VPCs matching .spec.vpc.tags, e.g. after adding a new VPC.
+	Rebalance *VpcRebalance `json:"rebalance,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=LeastUsed
+	// +kubebuilder:validation:Enum=LeastUsed;RoundRobin;AvailabilityZoneOverlap;QuotaHeadroom
+
+	// PlacementStrategy is how a VPC is selected among .spec.vpc.ids or the VPCs matching .spec.vpc.tags:
+	// LeastUsed | RoundRobin | AvailabilityZoneOverlap | QuotaHeadroom (defaults to LeastUsed).
+	// LeastUsed selects the VPC with the fewest Interface VPC Endpoints, RoundRobin cycles through the VPCs,
+	// AvailabilityZoneOverlap selects the VPC with private subnets in the most Availability Zones supported by the
+	// VPC Endpoint Service, and QuotaHeadroom selects the VPC furthest from .spec.vpc.endpointQuota.
+	PlacementStrategy string `json:"placementStrategy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+
+	// EndpointQuota is the maximum number of Interface VPC Endpoints per VPC used by the QuotaHeadroom placement
+	// strategy (defaults to 50, the default AWS quota). VPCs without headroom are never selected.
+	EndpointQuota int32 `json:"endpointQuota,omitempty"`
+}
+
+// VpcRebalance configures moving VPC Endpoints between the VPCs they are load balanced across. A VPC Endpoint is moved
+// by creating a new one in the under-used VPC before deleting the one in the over-used VPC, see .status.migration.
+type VpcRebalance struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+
+	// Enabled enables rebalancing
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=2
+
+	// SkewThreshold is the minimum difference between the number of VPC Endpoints in the VPC Endpoint's VPC and the
+	// least-used VPC for the VPC Endpoint to be moved (defaults to 2)
+	SkewThreshold int32 `json:"skewThreshold,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+
+	// MaxMovesPerInterval is the maximum number of VPC Endpoints that are moved away from the same set of VPCs
+	// within .spec.vpc.rebalance.interval (defaults to 1)
+	MaxMovesPerInterval int32 `json:"maxMovesPerInterval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1h"
+
+	// Interval is the period .spec.vpc.rebalance.maxMovesPerInterval applies to (defaults to 1h)
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// MaintenanceWindow restricts moving VPC Endpoints to a daily window, otherwise they may be moved at any time
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindow is a daily window of time in UTC
+type MaintenanceWindow struct {
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+
+	// Start is the time of day in UTC the window starts at, in the HH:MM format
+	Start string `json:"start"`
+
+	// Duration is how long the window lasts
+	Duration metav1.Duration `json:"duration"`
 }
 
 // ExternalNameService is the configuration of a Kubernetes ExternalName Service pointing to a CustomDns
@@ -107,11 +376,37 @@ type ExternalNameService struct {
 	Name string `json:"name"`
 }
 
+// +kubebuilder:validation:XValidation:message=a CNAME record cannot be created at the zone apex,rule=!(self.hostname == "@" && (!has(self.type) || self.type == "CNAME"))
+// +kubebuilder:validation:XValidation:message=availabilityZoneAffinity is not supported for wildcard records,rule=!(has(self.availabilityZoneAffinity) && self.availabilityZoneAffinity && self.hostname.startsWith("*"))
+
 // Route53HostedZoneRecord is the configuration of an AWS Route 53 Hosted Zone Record pointing to the created VPCE.
 type Route53HostedZoneRecord struct {
-	// Hostname is the hostname of the record.
+	// Hostname is the hostname of the record, "@" for the apex of the hosted zone, or a wildcard such as "*.apps".
 	Hostname string `json:"hostname"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=CNAME
+	// +kubebuilder:validation:Enum=CNAME;A;AAAA
+
+	// Type is the type of the record. CNAME records point to the VPC Endpoint's regional DNS name, while A and AAAA
+	// are alias records targeting the regional DNS name and its hosted zone, which can be created at the zone apex
+	// and are not charged for DNS queries. AAAA records require a dualstack or ipv6 VPC Endpoint.
+	Type string `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+
+	// TTL is the time to live of a CNAME record in seconds. Alias records use the TTL of the VPC Endpoint's DNS name.
+	TTL int64 `json:"ttl,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// AvailabilityZoneAffinity additionally creates a record named <availability zone>.<hostname> for each Availability
+	// Zone of the VPC Endpoint, pointing to the VPC Endpoint's zonal DNS name in that Availability Zone. Workloads can
+	// resolve the record of their own Availability Zone to avoid cross-AZ data transfer charges.
+	AvailabilityZoneAffinity bool `json:"availabilityZoneAffinity,omitempty"`
+
 	// +kubebuilder:validation:Optional
 
 	ExternalNameService ExternalNameService `json:"externalNameService,omitempty"`
@@ -197,6 +492,13 @@ type Route53PrivateHostedZone struct {
 
 	// Record is the configuration of a record within the selected Route 53 Private Hosted Zone
 	Record Route53HostedZoneRecord `json:"record,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:message=externalNameService is only supported in .spec.customDns.route53PrivateHostedZone.record,rule=self.all(r, !has(r.externalNameService))
+
+	// Records are additional records within the selected Route 53 Private Hosted Zone pointing to the VPC Endpoint, e.g.
+	// for the several hostnames of a hosted control plane
+	Records []Route53HostedZoneRecord `json:"records,omitempty"`
 }
 
 // CustomDns is the configuration of customized DNS routing external to a standalone AWS VPC Endpoint
@@ -225,6 +527,10 @@ type AwsEndpointSelector struct {
 // +kubebuilder:validation:XValidation:message=.spec.vpc.autoDiscoverSubnets is not supported with .spec.region,rule=!(has(self.region) && self.vpc.autoDiscoverSubnets)
 // +kubebuilder:validation:XValidation:message=.spec.customDns.route53PrivateHostedZone.autoDiscoverPrivateHostedZone is not supported with .spec.region,rule=!(has(self.region) && self.customDns.route53PrivateHostedZone.autoDiscoverPrivateHostedZone)
 // +kubebuilder:validation:XValidation:message="one of .spec.serviceName, .spec.serviceNameRef.name, or .spec.serviceNameRef.valueFrom.awsEndpointServiceRef.name must be specified",rule=has(self.serviceName) || (has(self.serviceNameRef) && has(self.serviceNameRef.name)) || (!has(self.serviceNameRef.valueFrom) || !has(self.serviceNameRef.valueFrom.awsEndpointServiceRef) || has(self.serviceNameRef.valueFrom.awsEndpointServiceRef.name))
+// +kubebuilder:validation:XValidation:message=.spec.vpc.subnetIds must contain exactly one subnet for GatewayLoadBalancer VPC Endpoints,rule=!(has(self.type) && self.type == 'GatewayLoadBalancer' && has(self.vpc) && has(self.vpc.subnetIds) && size(self.vpc.subnetIds) != 1)
+// +kubebuilder:validation:XValidation:message=.spec.ipAddressType is not supported for Gateway VPC Endpoints,rule=!(has(self.type) && self.type == 'Gateway' && has(self.ipAddressType))
+// +kubebuilder:validation:XValidation:message=.spec.policy is not supported for GatewayLoadBalancer VPC Endpoints,rule=!(has(self.type) && self.type == 'GatewayLoadBalancer' && has(self.policy))
+// +kubebuilder:validation:XValidation:message=.spec.vpc.subnetConfigurations is not supported for Gateway VPC Endpoints,rule=!(has(self.type) && self.type == 'Gateway' && has(self.vpc) && has(self.vpc.subnetConfigurations))
 
 // VpcEndpointSpec defines the desired state of VpcEndpoint
 type VpcEndpointSpec struct {
@@ -239,12 +545,26 @@ type VpcEndpointSpec struct {
 
 	// +kubebuilder:validation:Optional
 
-	// AssumeRoleArn will allow AVO to use sts:AssumeRole to create VPC Endpoints in separate AWS Accounts
-	// TODO: Implement
+	// AssumeRoleArn will allow AVO to use sts:AssumeRole to create VPC Endpoints in separate AWS Accounts.
+	// The assumed role is used for all EC2 and Route 53 API calls made while reconciling this VpcEndpoint.
+	// If .spec.awsCredentialOverrideRef is also specified, those credentials are used to assume the role.
 	AssumeRoleArn string `json:"assumeRoleArn,omitempty"`
 
 	// +kubebuilder:validation:Optional
 
+	// AssumeRoleExternalId is an optional external ID to pass to sts:AssumeRole when assuming .spec.assumeRoleArn
+	// Ref: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_roles_create_for-user_externalid.html
+	AssumeRoleExternalId string `json:"assumeRoleExternalId,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=64
+
+	// AssumeRoleSessionName is an optional session name to use when assuming .spec.assumeRoleArn
+	// Defaults to "aws-vpce-operator"
+	AssumeRoleSessionName string `json:"assumeRoleSessionName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
 	// AWSCredentialOverride is a Kubernetes secret containing AWS credentials for the operator to use for reconciling
 	// this specific vpcendpoint Custom Resource.
 	// The secret should have data keys for either:
@@ -263,13 +583,65 @@ type VpcEndpointSpec struct {
 
 	// EnablePrivateDns will allow AVO to create VPC Endpoints with private DNS names specified by a VPC Endpoint Service
 	// https://docs.aws.amazon.com/vpc/latest/privatelink/manage-dns-names.html (defaults to false)
-	// TODO: Implement
+	// Private DNS can only be enabled once the VPC Endpoint Service's private DNS name has been verified.
 	EnablePrivateDns bool `json:"enablePrivateDns,omitempty"`
 
+	// +kubebuilder:validation:Optional
+
+	// DnsOptions configures the DNS options of the VPC Endpoint. If unspecified, AWS defaults are used.
+	DnsOptions *DnsOptions `json:"dnsOptions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ipv4;dualstack;ipv6
+
+	// IpAddressType is the IP address type of the VPC Endpoint: ipv4 | dualstack | ipv6 (defaults to ipv4).
+	// The subnets of the VPC Endpoint must have IPv6 CIDR blocks for dualstack and ipv6. The IP address type of the
+	// VPC Endpoint's DNS records is configured separately with .spec.dnsOptions.dnsRecordIpType.
+	// Not supported for Gateway VPC Endpoints.
+	IpAddressType string `json:"ipAddressType,omitempty"`
+
+	// +kubebuilder:default=Interface
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Interface;Gateway;GatewayLoadBalancer
+	// +kubebuilder:validation:XValidation:message=.spec.type is immutable,rule=self == oldSelf
+
+	// Type is the type of VPC Endpoint to create, Interface | Gateway | GatewayLoadBalancer (defaults to Interface).
+	// Gateway VPC Endpoints are only supported by Amazon S3 and DynamoDB and are associated with route tables
+	// instead of subnets and security groups.
+	// GatewayLoadBalancer VPC Endpoints are created in exactly one subnet without a security group, and their
+	// network interfaces are reported in .status.networkInterfaceIds so that route tables can target them.
+	// Ref: https://docs.aws.amazon.com/vpc/latest/privatelink/gateway-endpoints.html
+	// Ref: https://docs.aws.amazon.com/vpc/latest/privatelink/gateway-load-balancer-endpoints.html
+	Type string `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Policy is the policy document attached to the VPC Endpoint to control access to the VPC Endpoint Service.
+	// If unspecified, the VPC Endpoint's policy is not managed and AWS defaults to allowing full access.
+	// Drift from the specified policy is corrected on every reconcile.
+	Policy *Policy `json:"policy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=OnRejected
+	// +kubebuilder:validation:Enum=Never;OnRejected;OnFailed
+
+	// RecreatePolicy is when AVO deletes and recreates the VPC Endpoint: Never | OnRejected | OnFailed
+	// (defaults to OnRejected). OnRejected recreates the VPC Endpoint when its connection is rejected by the VPC Endpoint
+	// Service, OnFailed when its connection is rejected or the VPC Endpoint failed. Each re-creation emits an Event
+	// and is recorded in .status.recreateAttempts and .status.lastFailureReason.
+	RecreatePolicy string `json:"recreatePolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// RecreateBackoff configures the maximum number of re-creations and the delay between them
+	RecreateBackoff *RecreateBackoff `json:"recreateBackoff,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:message=.spec.vpc.autoDiscoverSubnets must be true when specifying tags to search for VPCs,rule=!(size(self.tags) > 0 && !self.autoDiscoverSubnets)
 	// +kubebuilder:validation:XValidation:message=.spec.vpc.autoDiscoverSubnets must be true when specifying VPCs to load balance,rule=!(size(self.ids) > 0 && !self.autoDiscoverSubnets)
 	// +kubebuilder:validation:XValidation:message=.spec.vpc.subnetIds is not supported when specifying VPCs to load balance,rule=!(size(self.ids) > 0 && has(self.subnetIds) && size(self.subnetIds) > 0)
+	// +kubebuilder:validation:XValidation:message=cannot set both .spec.vpc.routeTableIds and .spec.vpc.routeTableTags,rule=!(has(self.routeTableIds) && has(self.routeTableTags))
+	// +kubebuilder:validation:XValidation:message=.spec.vpc.preferredSubnetTag must be specified when .spec.vpc.subnetSelectionPolicy is PreferredTag,rule=!(has(self.subnetSelectionPolicy) && self.subnetSelectionPolicy == 'PreferredTag' && !has(self.preferredSubnetTag))
 
 	// Vpc will allow AVO to use a specific VPC or use the same VPC as the ROSA cluster it's running on
 	Vpc Vpc `json:"vpc,omitempty"`
@@ -282,12 +654,35 @@ type VpcEndpointSpec struct {
 }
 
 const (
-	AWSVpcEndpointCondition      = "AWSVpcEndpointReady"
-	AWSSecurityGroupCondition    = "AWSSecurityGroupReady"
-	ExternalNameServiceCondition = "ExternalNameServiceReady"
-	AWSRoute53RecordCondition    = "AWSRoute53RecordReady"
+	AWSAssumeRoleCondition             = "AWSAssumeRoleReady"
+	AWSVpcEndpointCondition            = "AWSVpcEndpointReady"
+	AWSSecurityGroupCondition          = "AWSSecurityGroupReady"
+	AWSPrivateDnsCondition             = "AWSPrivateDnsReady"
+	AWSVpcEndpointPolicyCondition      = "AWSVpcEndpointPolicyReady"
+	AWSVpcMigrationCondition           = "AWSVpcMigrationComplete"
+	AWSServiceAZCoverageCondition      = "AWSServiceAvailabilityZonesCovered"
+	AZCoverageCondition                = "AZCoverage"
+	SubnetCapacityCondition            = "SubnetCapacity"
+	ExternalNameServiceCondition       = "ExternalNameServiceReady"
+	AWSRoute53RecordCondition          = "AWSRoute53RecordReady"
+	AWSRoute53RecordOwnershipCondition = "AWSRoute53RecordOwned"
 )
 
+// VpcMigrationStatus records the AWS resources in the previous VPC while migrating a VpcEndpoint to another VPC
+type VpcMigrationStatus struct {
+	// The AWS ID of the VPC being migrated away from
+	// +kubebuilder:validation:Optional
+	VPCId string `json:"vpcId,omitempty"`
+
+	// The AWS ID of the VPC Endpoint in the previous VPC, deleted once the migration is complete
+	// +kubebuilder:validation:Optional
+	VPCEndpointId string `json:"vpcEndpointId,omitempty"`
+
+	// The AWS ID of the managed security group in the previous VPC, deleted once the migration is complete
+	// +kubebuilder:validation:Optional
+	SecurityGroupId string `json:"securityGroupId,omitempty"`
+}
+
 // VpcEndpointStatus defines the observed state of VpcEndpoint
 type VpcEndpointStatus struct {
 	// Status of the VPC Endpoint
@@ -297,18 +692,68 @@ type VpcEndpointStatus struct {
 	// +kubebuilder:validation:Optional
 	SecurityGroupId string `json:"securityGroupId,omitempty"`
 
+	// The AWS IDs of the additional security groups attached to the VPC Endpoint
+	// +kubebuilder:validation:Optional
+	AdditionalSecurityGroupIds []string `json:"additionalSecurityGroupIds,omitempty"`
+
 	// The AWS ID of the VPC to create resources in
 	// +kubebuilder:validation:Optional
 	VPCId string `json:"vpcId,omitempty"`
 
+	// The AWS resources in the previous VPC while the VpcEndpoint is migrated to .status.vpcId after its VPC selection
+	// in .spec.vpc changed. They are deleted once the VPC Endpoint in .status.vpcId is available.
+	// +kubebuilder:validation:Optional
+	Migration *VpcMigrationStatus `json:"migration,omitempty"`
+
+	// The reason .status.vpcId was selected according to .spec.vpc.placementStrategy
+	// +kubebuilder:validation:Optional
+	PlacementReason string `json:"placementReason,omitempty"`
+
+	// The .metadata.generation .status.vpcId was last selected for. .spec.vpc is only re-evaluated when it changes.
+	// +kubebuilder:validation:Optional
+	VPCSelectionGeneration int64 `json:"vpcSelectionGeneration,omitempty"`
+
 	// The AWS ID of the managed VPC Endpoint
 	// +kubebuilder:validation:Optional
 	VPCEndpointId string `json:"vpcEndpointId,omitempty"`
 
+	// The AWS IDs of the network interfaces of the managed VPC Endpoint
+	// +kubebuilder:validation:Optional
+	NetworkInterfaceIds []string `json:"networkInterfaceIds,omitempty"`
+
+	// The AWS IDs of the network interfaces removed from the VPC Endpoint to change their static IP addresses. Their
+	// subnets are added back once they are deleted.
+	// +kubebuilder:validation:Optional
+	ReplacedNetworkInterfaceIds []string `json:"replacedNetworkInterfaceIds,omitempty"`
+
+	// The auto-discovered subnet selected in each Availability Zone according to .spec.vpc.subnetSelectionPolicy
+	// +kubebuilder:validation:Optional
+	AvailabilityZoneSubnets map[string]string `json:"availabilityZoneSubnets,omitempty"`
+
+	// The number of times the VPC Endpoint was recreated according to .spec.recreatePolicy since it was last available
+	// +kubebuilder:validation:Optional
+	RecreateAttempts int32 `json:"recreateAttempts,omitempty"`
+
+	// The reason the VPC Endpoint was last recreated, or could not be recreated
+	// +kubebuilder:validation:Optional
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
+
+	// The last time the VPC Endpoint was recreated
+	// +kubebuilder:validation:Optional
+	LastRecreateTime *metav1.Time `json:"lastRecreateTime,omitempty"`
+
 	// The name of the VPC Endpoint Service the VPC Endpoint connects to
 	// +kubebuilder:validation:Optional
 	VPCEndpointServiceName string `json:"vpcEndpointServiceName,omitempty"`
 
+	// The private DNS name of the VPC Endpoint Service the VPC Endpoint connects to, if it has one
+	// +kubebuilder:validation:Optional
+	PrivateDnsName string `json:"privateDnsName,omitempty"`
+
+	// The verification state of the VPC Endpoint Service's private DNS name: pendingVerification | verified | failed
+	// +kubebuilder:validation:Optional
+	PrivateDnsNameVerificationState string `json:"privateDnsNameVerificationState,omitempty"`
+
 	// The AWS ID of the Route 53 Private Hosted Zone being used
 	// +kubebuilder:validation:Optional
 	HostedZoneId string `json:"hostedZoneId,omitempty"`
@@ -317,6 +762,19 @@ type VpcEndpointStatus struct {
 	// +kubebuilder:validation:Optional
 	ResourceRecordSet string `json:"resourceRecordSet,omitempty"`
 
+	// The FQDNs of all Route 53 Hosted Zone records that have been created, including .resourceRecordSet
+	// +kubebuilder:validation:Optional
+	ResourceRecordSets []string `json:"resourceRecordSets,omitempty"`
+
+	// The FQDNs of the Route 53 Hosted Zone records created for records with availabilityZoneAffinity, by Availability
+	// Zone
+	// +kubebuilder:validation:Optional
+	AvailabilityZoneRecords map[string][]string `json:"availabilityZoneRecords,omitempty"`
+
+	// The ID of the last change to the Route 53 Hosted Zone records while it is propagating
+	// +kubebuilder:validation:Optional
+	Route53ChangeId string `json:"route53ChangeId,omitempty"`
+
 	// The Infra Id of the cluster, used for naming and tagging purposes
 	// +kubebuilder:validation:Optional
 	InfraId string `json:"infraId,omitempty"`
@@ -61,6 +61,26 @@ func (in *CustomDns) DeepCopy() *CustomDns {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DnsOptions) DeepCopyInto(out *DnsOptions) {
+	*out = *in
+	if in.PrivateDnsOnlyForInboundResolverEndpoint != nil {
+		in, out := &in.PrivateDnsOnlyForInboundResolverEndpoint, &out.PrivateDnsOnlyForInboundResolverEndpoint
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DnsOptions.
+func (in *DnsOptions) DeepCopy() *DnsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DnsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DnsSelector) DeepCopyInto(out *DnsSelector) {
 	*out = *in
@@ -156,6 +176,22 @@ func (in *HostedControlPlaneSelector) DeepCopy() *HostedControlPlaneSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectFieldSelector) DeepCopyInto(out *ObjectFieldSelector) {
 	*out = *in
@@ -171,6 +207,43 @@ func (in *ObjectFieldSelector) DeepCopy() *ObjectFieldSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Policy) DeepCopyInto(out *Policy) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Policy.
+func (in *Policy) DeepCopy() *Policy {
+	if in == nil {
+		return nil
+	}
+	out := new(Policy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecreateBackoff) DeepCopyInto(out *RecreateBackoff) {
+	*out = *in
+	out.InitialDelay = in.InitialDelay
+	out.MaxDelay = in.MaxDelay
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecreateBackoff.
+func (in *RecreateBackoff) DeepCopy() *RecreateBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(RecreateBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route53HostedZoneRecord) DeepCopyInto(out *Route53HostedZoneRecord) {
 	*out = *in
@@ -203,6 +276,11 @@ func (in *Route53PrivateHostedZone) DeepCopyInto(out *Route53PrivateHostedZone)
 		(*in).DeepCopyInto(*out)
 	}
 	out.Record = in.Record
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]Route53HostedZoneRecord, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route53PrivateHostedZone.
@@ -218,15 +296,29 @@ func (in *Route53PrivateHostedZone) DeepCopy() *Route53PrivateHostedZone {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
+	if in.AdditionalSecurityGroupIds != nil {
+		in, out := &in.AdditionalSecurityGroupIds, &out.AdditionalSecurityGroupIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalSecurityGroupTags != nil {
+		in, out := &in.AdditionalSecurityGroupTags, &out.AdditionalSecurityGroupTags
+		*out = make([]Tag, len(*in))
+		copy(*out, *in)
+	}
 	if in.IngressRules != nil {
 		in, out := &in.IngressRules, &out.IngressRules
 		*out = make([]SecurityGroupRule, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.EgressRules != nil {
 		in, out := &in.EgressRules, &out.EgressRules
 		*out = make([]SecurityGroupRule, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -243,6 +335,21 @@ func (in *SecurityGroup) DeepCopy() *SecurityGroup {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
 	*out = *in
+	if in.Cidrs != nil {
+		in, out := &in.Cidrs, &out.Cidrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceSecurityGroupIds != nil {
+		in, out := &in.SourceSecurityGroupIds, &out.SourceSecurityGroupIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrefixListIds != nil {
+		in, out := &in.PrefixListIds, &out.PrefixListIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRule.
@@ -295,6 +402,21 @@ func (in *ServiceNameSource) DeepCopy() *ServiceNameSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetConfiguration) DeepCopyInto(out *SubnetConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetConfiguration.
+func (in *SubnetConfiguration) DeepCopy() *SubnetConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tag) DeepCopyInto(out *Tag) {
 	*out = *in
@@ -318,6 +440,16 @@ func (in *Vpc) DeepCopyInto(out *Vpc) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SubnetConfigurations != nil {
+		in, out := &in.SubnetConfigurations, &out.SubnetConfigurations
+		*out = make([]SubnetConfiguration, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreferredSubnetTag != nil {
+		in, out := &in.PreferredSubnetTag, &out.PreferredSubnetTag
+		*out = new(Tag)
+		**out = **in
+	}
 	if in.Ids != nil {
 		in, out := &in.Ids, &out.Ids
 		*out = make([]string, len(*in))
@@ -333,6 +465,21 @@ func (in *Vpc) DeepCopyInto(out *Vpc) {
 		*out = make([]Tag, len(*in))
 		copy(*out, *in)
 	}
+	if in.RouteTableIds != nil {
+		in, out := &in.RouteTableIds, &out.RouteTableIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RouteTableTags != nil {
+		in, out := &in.RouteTableTags, &out.RouteTableTags
+		*out = make([]Tag, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rebalance != nil {
+		in, out := &in.Rebalance, &out.Rebalance
+		*out = new(VpcRebalance)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vpc.
@@ -418,6 +565,21 @@ func (in *VpcEndpointSpec) DeepCopyInto(out *VpcEndpointSpec) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.DnsOptions != nil {
+		in, out := &in.DnsOptions, &out.DnsOptions
+		*out = new(DnsOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(Policy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecreateBackoff != nil {
+		in, out := &in.RecreateBackoff, &out.RecreateBackoff
+		*out = new(RecreateBackoff)
+		**out = **in
+	}
 	in.Vpc.DeepCopyInto(&out.Vpc)
 	in.CustomDns.DeepCopyInto(&out.CustomDns)
 }
@@ -435,6 +597,58 @@ func (in *VpcEndpointSpec) DeepCopy() *VpcEndpointSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcEndpointStatus) DeepCopyInto(out *VpcEndpointStatus) {
 	*out = *in
+	if in.AdditionalSecurityGroupIds != nil {
+		in, out := &in.AdditionalSecurityGroupIds, &out.AdditionalSecurityGroupIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(VpcMigrationStatus)
+		**out = **in
+	}
+	if in.NetworkInterfaceIds != nil {
+		in, out := &in.NetworkInterfaceIds, &out.NetworkInterfaceIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplacedNetworkInterfaceIds != nil {
+		in, out := &in.ReplacedNetworkInterfaceIds, &out.ReplacedNetworkInterfaceIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailabilityZoneSubnets != nil {
+		in, out := &in.AvailabilityZoneSubnets, &out.AvailabilityZoneSubnets
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastRecreateTime != nil {
+		in, out := &in.LastRecreateTime, &out.LastRecreateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourceRecordSets != nil {
+		in, out := &in.ResourceRecordSets, &out.ResourceRecordSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailabilityZoneRecords != nil {
+		in, out := &in.AvailabilityZoneRecords, &out.AvailabilityZoneRecords
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -545,6 +759,42 @@ func (in *VpcEndpointTemplateStatus) DeepCopy() *VpcEndpointTemplateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcMigrationStatus) DeepCopyInto(out *VpcMigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcMigrationStatus.
+func (in *VpcMigrationStatus) DeepCopy() *VpcMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcRebalance) DeepCopyInto(out *VpcRebalance) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcRebalance.
+func (in *VpcRebalance) DeepCopy() *VpcRebalance {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcRebalance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpceTemplateSpec) DeepCopyInto(out *VpceTemplateSpec) {
 	*out = *in
@@ -0,0 +1,168 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/secrets"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// assumeRoleCacheKey uniquely identifies a set of assumed role credentials
+type assumeRoleCacheKey struct {
+	roleArn     string
+	externalId  string
+	sessionName string
+	region      string
+	// credentialOverride is the namespace/name of the .spec.awsCredentialOverrideRef secret used to assume the role,
+	// empty if the controller's default credentials are used instead
+	credentialOverride string
+}
+
+// assumeRoleCache holds AWS configs with assumed role credentials so that they are not rebuilt on every reconcile.
+// The credentials inside each config are wrapped in an aws.CredentialsCache, so they are refreshed automatically
+// as they expire.
+type assumeRoleCache struct {
+	mu      sync.Mutex
+	configs map[assumeRoleCacheKey]aws.Config
+}
+
+func (c *assumeRoleCache) get(key assumeRoleCacheKey) (aws.Config, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfg, ok := c.configs[key]
+	return cfg, ok
+}
+
+func (c *assumeRoleCache) set(key assumeRoleCacheKey, cfg aws.Config) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.configs == nil {
+		c.configs = map[assumeRoleCacheKey]aws.Config{}
+	}
+	c.configs[key] = cfg
+}
+
+func (c *assumeRoleCache) delete(key assumeRoleCacheKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.configs, key)
+}
+
+// newAssumeRoleCacheKey generates the assumeRoleCacheKey for a VpcEndpoint in a given region
+func newAssumeRoleCacheKey(vpce *avov1alpha2.VpcEndpoint, region string) assumeRoleCacheKey {
+	key := assumeRoleCacheKey{
+		roleArn:     vpce.Spec.AssumeRoleArn,
+		externalId:  vpce.Spec.AssumeRoleExternalId,
+		sessionName: vpce.Spec.AssumeRoleSessionName,
+		region:      region,
+	}
+
+	if key.sessionName == "" {
+		key.sessionName = defaultAssumeRoleSessionName
+	}
+
+	if vpce.Spec.AWSCredentialOverrideRef != nil {
+		key.credentialOverride = fmt.Sprintf("%s/%s", vpce.Spec.AWSCredentialOverrideRef.Namespace, vpce.Spec.AWSCredentialOverrideRef.Name)
+	}
+
+	return key
+}
+
+// assumeRole returns an AWS config using credentials from assuming .spec.assumeRoleArn, reusing cached
+// credentials for the same role when possible. The AWSAssumeRoleReady condition is updated to reflect
+// whether the role could be assumed.
+func (r *VpcEndpointReconciler) assumeRole(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) (aws.Config, error) {
+	key := newAssumeRoleCacheKey(vpce, r.clusterInfo.region)
+	cfg, ok := r.assumeRoleCache.get(key)
+	if !ok {
+		r.log.V(1).Info("Building AWS credentials to assume role", "roleArn", key.roleArn, "sessionName", key.sessionName)
+		sourceCfg, err := r.assumeRoleSourceConfig(ctx, vpce)
+		if err != nil {
+			return aws.Config{}, r.setAssumeRoleFailedCondition(ctx, vpce, err)
+		}
+
+		cfg = sourceCfg.Copy()
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(sourceCfg), key.roleArn, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = key.sessionName
+			if key.externalId != "" {
+				o.ExternalID = aws.String(key.externalId)
+			}
+		}))
+	}
+
+	// Retrieving credentials is a no-op while the cached credentials are still valid, otherwise this is where
+	// sts:AssumeRole is actually called, so we can surface failures before making any other AWS API calls
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		r.assumeRoleCache.delete(key)
+		return aws.Config{}, r.setAssumeRoleFailedCondition(ctx, vpce, err)
+	}
+	r.assumeRoleCache.set(key, cfg)
+
+	// Only update the status when the condition changes, this runs on every reconcile
+	if meta.SetStatusCondition(&vpce.Status.Conditions, metav1.Condition{
+		Type:    avov1alpha2.AWSAssumeRoleCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Assumed",
+		Message: fmt.Sprintf("Assumed role: %s", key.roleArn),
+	}) {
+		if err := r.Status().Update(ctx, vpce); err != nil {
+			return aws.Config{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// assumeRoleSourceConfig returns the AWS config whose credentials will be used to assume .spec.assumeRoleArn
+func (r *VpcEndpointReconciler) assumeRoleSourceConfig(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) (aws.Config, error) {
+	if vpce.Spec.AWSCredentialOverrideRef != nil {
+		return secrets.ParseAWSCredentialOverride(ctx, r.APIReader, r.clusterInfo.region, vpce.Spec.AWSCredentialOverrideRef)
+	}
+
+	return config.LoadDefaultConfig(ctx, config.WithRegion(r.clusterInfo.region))
+}
+
+// setAssumeRoleFailedCondition records a failure to assume .spec.assumeRoleArn in the VpcEndpoint's status and
+// returns the original error wrapped with additional context
+func (r *VpcEndpointReconciler) setAssumeRoleFailedCondition(ctx context.Context, vpce *avov1alpha2.VpcEndpoint, err error) error {
+	r.log.V(0).Info("Failed to assume role", "roleArn", vpce.Spec.AssumeRoleArn, "error", err.Error())
+	if meta.SetStatusCondition(&vpce.Status.Conditions, metav1.Condition{
+		Type:    avov1alpha2.AWSAssumeRoleCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "AssumeRoleFailed",
+		Message: fmt.Sprintf("Failed to assume role %s: %v", vpce.Spec.AssumeRoleArn, err),
+	}) {
+		if err := r.Status().Update(ctx, vpce); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+		}
+	}
+
+	return fmt.Errorf("failed to assume role %s: %w", vpce.Spec.AssumeRoleArn, err)
+}
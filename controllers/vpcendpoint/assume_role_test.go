@@ -0,0 +1,150 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/go-logr/logr/testr"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestNewAssumeRoleCacheKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     avov1alpha2.VpcEndpointSpec
+		expected assumeRoleCacheKey
+	}{
+		{
+			name: "default session name",
+			spec: avov1alpha2.VpcEndpointSpec{
+				AssumeRoleArn: "arn:aws:iam::123456789012:role/mock",
+			},
+			expected: assumeRoleCacheKey{
+				roleArn:     "arn:aws:iam::123456789012:role/mock",
+				sessionName: defaultAssumeRoleSessionName,
+				region:      testutil.MockAWSRegion,
+			},
+		},
+		{
+			name: "all options",
+			spec: avov1alpha2.VpcEndpointSpec{
+				AssumeRoleArn:         "arn:aws:iam::123456789012:role/mock",
+				AssumeRoleExternalId:  "external-id",
+				AssumeRoleSessionName: "session",
+				AWSCredentialOverrideRef: &corev1.SecretReference{
+					Name:      "override",
+					Namespace: "override-ns",
+				},
+			},
+			expected: assumeRoleCacheKey{
+				roleArn:            "arn:aws:iam::123456789012:role/mock",
+				externalId:         "external-id",
+				sessionName:        "session",
+				region:             testutil.MockAWSRegion,
+				credentialOverride: "override-ns/override",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := newAssumeRoleCacheKey(&avov1alpha2.VpcEndpoint{Spec: test.spec}, testutil.MockAWSRegion)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_assumeRole(t *testing.T) {
+	tests := []struct {
+		name              string
+		credentials       aws.CredentialsProvider
+		expectErr         bool
+		expectedCondition metav1.ConditionStatus
+	}{
+		{
+			name:              "cached credentials",
+			credentials:       credentials.NewStaticCredentialsProvider("mock", "mock", ""),
+			expectErr:         false,
+			expectedCondition: metav1.ConditionTrue,
+		},
+		{
+			name: "cached credentials fail to refresh",
+			credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+				return aws.Credentials{}, errors.New("AccessDenied")
+			}),
+			expectErr:         true,
+			expectedCondition: metav1.ConditionFalse,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					AssumeRoleArn: "arn:aws:iam::123456789012:role/mock",
+				},
+			}
+
+			r := &VpcEndpointReconciler{
+				Client:          testutil.NewTestMock(t, resource).Client,
+				Scheme:          testutil.NewTestMock(t).Client.Scheme(),
+				Recorder:        record.NewFakeRecorder(1),
+				log:             testr.New(t),
+				clusterInfo:     &clusterInfo{region: testutil.MockAWSRegion},
+				assumeRoleCache: new(assumeRoleCache),
+			}
+
+			key := newAssumeRoleCacheKey(resource, testutil.MockAWSRegion)
+			r.assumeRoleCache.set(key, aws.Config{Region: testutil.MockAWSRegion, Credentials: test.credentials})
+
+			_, err := r.assumeRole(context.TODO(), resource)
+			if test.expectErr {
+				assert.Error(t, err)
+				_, ok := r.assumeRoleCache.get(key)
+				assert.False(t, ok, "expected failed credentials to be evicted from the cache")
+			} else {
+				assert.NoError(t, err)
+
+				// The status is only updated when the condition changes
+				resourceVersion := resource.ResourceVersion
+				_, err = r.assumeRole(context.TODO(), resource)
+				assert.NoError(t, err)
+				assert.Equal(t, resourceVersion, resource.ResourceVersion)
+			}
+
+			cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSAssumeRoleCondition)
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, test.expectedCondition, cond.Status)
+			}
+		})
+	}
+}
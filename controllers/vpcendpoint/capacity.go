@@ -0,0 +1,80 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkSubnetCapacity records the free IP addresses of the VPC Endpoint's expectedSubnetIds in the
+// subnetFreeIpAddresses metric and sets the SubnetCapacity condition. Creating the VPC Endpoint's network interface in
+// a subnet consumes one of its free IP addresses, otherwise AWS fails with InsufficientFreeAddressesInSubnet, so it
+// returns subnetsToAdd without the subnets that have fewer than .spec.vpc.minFreeIpAddresses. Subnets that are
+// replaced free up their current IP address, so they are always kept.
+func (r *VpcEndpointReconciler) checkSubnetCapacity(ctx context.Context, resource *avov1alpha2.VpcEndpoint, expectedSubnetIds, subnetsToAdd, replaced []string) ([]string, error) {
+	counts, err := r.awsClient.SubnetAvailableIpAddressCounts(ctx, expectedSubnetIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subnetId := range expectedSubnetIds {
+		subnetFreeIpAddresses.WithLabelValues(subnetId).Set(float64(counts[subnetId]))
+	}
+
+	var (
+		toAdd []string
+		full  []string
+	)
+	for _, subnetId := range subnetsToAdd {
+		if counts[subnetId] < resource.Spec.Vpc.MinFreeIpAddresses && !slices.Contains(replaced, subnetId) {
+			full = append(full, fmt.Sprintf("%s (%d free)", subnetId, counts[subnetId]))
+			continue
+		}
+
+		toAdd = append(toAdd, subnetId)
+	}
+
+	if len(full) > 0 {
+		message := fmt.Sprintf("Not adding the VPC Endpoint to subnet(s) with fewer than %d free IP address(es): %s",
+			resource.Spec.Vpc.MinFreeIpAddresses, strings.Join(full, ", "))
+		r.log.V(0).Info("Subnet(s) without enough free IP addresses", "reason", message)
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.SubnetCapacityCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InsufficientFreeAddressesInSubnet",
+			Message: message,
+		})
+
+		return toAdd, nil
+	}
+
+	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+		Type:    avov1alpha2.SubnetCapacityCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Sufficient",
+		Message: fmt.Sprintf("No subnets were skipped for having fewer than %d free IP address(es)", resource.Spec.Vpc.MinFreeIpAddresses),
+	})
+
+	return toAdd, nil
+}
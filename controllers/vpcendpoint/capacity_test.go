@@ -0,0 +1,91 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr/testr"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVpcEndpointReconciler_checkSubnetCapacity(t *testing.T) {
+	subnets := []*ec2Types.Subnet{
+		{SubnetId: aws.String("subnet-full"), AvailableIpAddressCount: aws.Int32(0)},
+		{SubnetId: aws.String("subnet-free"), AvailableIpAddressCount: aws.Int32(100)},
+	}
+
+	tests := []struct {
+		name           string
+		subnetsToAdd   []string
+		replaced       []string
+		expected       []string
+		expectedStatus metav1.ConditionStatus
+	}{
+		{
+			name:           "enough free IP addresses",
+			subnetsToAdd:   []string{"subnet-free"},
+			expected:       []string{"subnet-free"},
+			expectedStatus: metav1.ConditionTrue,
+		},
+		{
+			name:           "full subnet skipped",
+			subnetsToAdd:   []string{"subnet-full", "subnet-free"},
+			expected:       []string{"subnet-free"},
+			expectedStatus: metav1.ConditionFalse,
+		},
+		{
+			name:           "full subnet replaced",
+			subnetsToAdd:   []string{"subnet-full"},
+			replaced:       []string{"subnet-full"},
+			expected:       []string{"subnet-full"},
+			expectedStatus: metav1.ConditionTrue,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &VpcEndpointReconciler{
+				log:       testr.New(t),
+				awsClient: aws_client.NewAwsClientWithServiceClients(&aws_client.MockedEC2{Subnets: subnets}, &aws_client.MockedRoute53{}),
+			}
+			resource := &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Vpc: avov1alpha2.Vpc{MinFreeIpAddresses: 1},
+				},
+			}
+
+			actual, err := r.checkSubnetCapacity(context.TODO(), resource, []string{"subnet-full", "subnet-free"}, test.subnetsToAdd, test.replaced)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, float64(100), testutil.ToFloat64(subnetFreeIpAddresses.WithLabelValues("subnet-free")))
+
+			cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.SubnetCapacityCondition)
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, test.expectedStatus, cond.Status)
+			}
+		})
+	}
+}
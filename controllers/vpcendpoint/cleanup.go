@@ -20,10 +20,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/aws/smithy-go"
 	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,52 +36,49 @@ import (
 
 // cleanupAwsResources cleans up AWS resources associated with a VPC Endpoint.
 func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
-	if meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition) {
-		// Ensure .status.hostedZoneId is populated
+	if resource.Status.HostedZoneId == "" && len(ownedRoute53RecordNames(resource)) > 0 {
+		// Ensure .status.hostedZoneId is populated so that the records aren't orphaned
 		if err := r.validateR53PrivateHostedZone(ctx, resource); err != nil {
 			return err
 		}
+	}
 
-		// HostedZoneId and resourceRecord are required if we want to clean up a ResourceRecordSet
-		if resource.Status.HostedZoneId != "" && resource.Status.ResourceRecordSet != "" {
-			resp, err := r.awsClient.GetHostedZone(ctx, resource.Status.HostedZoneId)
+	// HostedZoneId and resourceRecords are required if we want to clean up ResourceRecordSets. Records are cleaned up
+	// regardless of the AWSRoute53RecordReady condition, which is False while a change is propagating or conflicts.
+	if resource.Status.HostedZoneId != "" && len(ownedRoute53RecordNames(resource)) > 0 {
+		resp, err := r.awsClient.GetHostedZone(ctx, resource.Status.HostedZoneId)
+		if err != nil {
+			return err
+		}
+
+		if resp.HostedZone != nil {
+			index, err := r.listRoute53Records(ctx, *resp.HostedZone.Id)
 			if err != nil {
 				return err
 			}
 
-			if resp.HostedZone != nil {
-				listRRSResp, err := r.awsClient.ListResourceRecordSets(ctx, resource.Status.HostedZoneId)
-				if err != nil {
+			// Delete every record created for the VPC Endpoint
+			for _, name := range ownedRoute53RecordNames(resource) {
+				if err := r.deleteRoute53Records(ctx, resource, *resp.HostedZone.Id, index, name); err != nil {
 					return err
 				}
-
-				// Delete all records in the hosted zone except the default SOA and NS records
-				for _, resourceRecord := range listRRSResp.ResourceRecordSets {
-					rr := resourceRecord
-					switch *rr.Name {
-					case fmt.Sprintf("%s.", resource.Status.ResourceRecordSet):
-						r.log.V(0).Info("Deleting Route53 Hosted Zone Record", "name", *resourceRecord.Name, "type", resourceRecord.Type)
-						if _, err := r.awsClient.DeleteResourceRecordSet(ctx, &rr, *resp.HostedZone.Id); err != nil {
-							return err
-						}
-					default:
-						continue
-					}
-				}
 			}
+		}
 
-			resource.Status.ResourceRecordSet = ""
-			meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-				Type:    avov1alpha2.AWSRoute53RecordCondition,
-				Status:  metav1.ConditionFalse,
-				Reason:  "Deleted",
-				Message: "Deleted Route53 Hosted Zone Record",
-			})
+		resource.Status.ResourceRecordSet = ""
+		resource.Status.ResourceRecordSets = nil
+		resource.Status.AvailabilityZoneRecords = nil
+		resource.Status.Route53ChangeId = ""
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSRoute53RecordCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Deleted",
+			Message: "Deleted Route53 Hosted Zone Record",
+		})
 
-			if err := r.Status().Update(ctx, resource); err != nil {
-				r.log.V(0).Error(err, "failed to update status")
-				return err
-			}
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
 		}
 	}
 
@@ -109,27 +108,35 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 								return err
 							}
 						case new(route53Types.HostedZoneNotEmpty).ErrorCode():
-							// If there are other records in this hosted zone, delete them so that we can delete the
-							// hosted zone that we own
-							listRRSResp, err := r.awsClient.ListResourceRecordSets(ctx, resource.Status.HostedZoneId)
+							// If there are other records in this hosted zone, delete the ones we own so that we can
+							// delete the hosted zone that we own
+							foreign, err := r.deleteOwnedRoute53Records(ctx, resource)
 							if err != nil {
 								return err
 							}
 
-							// Delete all records in the hosted zone except the default SOA and NS records
-							for _, resourceRecord := range listRRSResp.ResourceRecordSets {
-								rr := resourceRecord
-								switch rr.Type {
-								case route53Types.RRTypeNs:
-									continue
-								case route53Types.RRTypeSoa:
-									continue
-								default:
-									r.log.V(0).Info("Deleting Route53 Hosted Zone Record", "name", *rr.Name, "type", rr.Type)
-									if _, err := r.awsClient.DeleteResourceRecordSet(ctx, &rr, resource.Status.HostedZoneId); err != nil {
-										return err
-									}
+							if len(foreign) > 0 {
+								// Keep the hosted zone for the records we don't own, but still clean up the VPC
+								// Endpoint and security group
+								r.log.V(0).Info("Not deleting Route53 Hosted Zone with records not owned by this VpcEndpoint", "id", resource.Status.HostedZoneId, "records", foreign)
+								r.Recorder.Eventf(resource, corev1.EventTypeWarning, "HostedZoneNotEmpty", "Not deleting Private Hosted Zone %s with records not owned by this VpcEndpoint: %s",
+									resource.Status.HostedZoneId, strings.Join(foreign, ", "))
+								meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+									Type:    avov1alpha2.AWSRoute53RecordOwnershipCondition,
+									Status:  metav1.ConditionFalse,
+									Reason:  "HostedZoneNotEmpty",
+									Message: fmt.Sprintf("Not deleting hosted zone with records owned by another VpcEndpoint or created outside of AVO: %s", strings.Join(foreign, ", ")),
+								})
+								if err := r.Status().Update(ctx, resource); err != nil {
+									r.log.V(0).Error(err, "failed to update status")
+									return err
 								}
+								break
+							}
+
+							// Now that the records we own are deleted, the hosted zone can be deleted
+							if _, err := r.awsClient.DeleteHostedZone(ctx, resource.Status.HostedZoneId); err != nil {
+								return &requeueAfterError{after: route53ChangeRequeueDelay, reason: fmt.Sprintf("waiting to delete hosted zone %s: %v", resource.Status.HostedZoneId, err)}
 							}
 						default:
 							return err
@@ -191,6 +198,11 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 		}
 	}
 
+	// Clean up anything left in the previous VPC by an unfinished migration
+	if err := r.cleanupVpcMigration(ctx, resource); err != nil {
+		return err
+	}
+
 	r.log.V(0).Info("AWS cleanup complete")
 	return nil
 }
@@ -31,9 +31,10 @@ import (
 
 func TestVpcEndpointReconciler_cleanupAwsResources(t *testing.T) {
 	tests := []struct {
-		name      string
-		resource  *avov1alpha2.VpcEndpoint
-		expectErr bool
+		name               string
+		resource           *avov1alpha2.VpcEndpoint
+		expectHostedZoneId string
+		expectErr          bool
 	}{
 		{
 			name: "all resources needing cleanup",
@@ -54,6 +55,71 @@ func TestVpcEndpointReconciler_cleanupAwsResources(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "multiple records",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock2",
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					HostedZoneId:       aws_client.MockHostedZoneId,
+					ResourceRecordSet:  "mock",
+					ResourceRecordSets: []string{"mock", "*.apps.mock"},
+					Conditions: []metav1.Condition{
+						{
+							Type:   avov1alpha2.AWSRoute53RecordCondition,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			expectHostedZoneId: aws_client.MockHostedZoneId,
+			expectErr:          false,
+		},
+		{
+			name: "record change pending",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock3",
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					HostedZoneId:       aws_client.MockHostedZoneId,
+					ResourceRecordSet:  "mock",
+					ResourceRecordSets: []string{"mock"},
+					Route53ChangeId:    aws_client.MockChangeId,
+					Conditions: []metav1.Condition{
+						{
+							Type:   avov1alpha2.AWSRoute53RecordCondition,
+							Status: metav1.ConditionFalse,
+							Reason: "Pending",
+						},
+					},
+				},
+			},
+			expectHostedZoneId: aws_client.MockHostedZoneId,
+			expectErr:          false,
+		},
+		{
+			name: "hosted zone id not persisted",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock4",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+							Id: aws_client.MockHostedZoneId,
+						},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					ResourceRecordSet:  "mock",
+					ResourceRecordSets: []string{"mock"},
+				},
+			},
+			expectHostedZoneId: "/hostedzone/" + aws_client.MockHostedZoneId,
+			expectErr:          false,
+		},
 	}
 
 	for _, test := range tests {
@@ -74,6 +140,10 @@ func TestVpcEndpointReconciler_cleanupAwsResources(t *testing.T) {
 			assert.Error(t, err)
 		} else {
 			assert.NoError(t, err)
+			assert.Empty(t, test.resource.Status.ResourceRecordSets)
+			assert.Empty(t, test.resource.Status.ResourceRecordSet)
+			assert.Empty(t, test.resource.Status.Route53ChangeId)
+			assert.Equal(t, test.expectHostedZoneId, test.resource.Status.HostedZoneId)
 		}
 	}
 }
@@ -16,9 +16,81 @@ limitations under the License.
 
 package vpcendpoint
 
+import "time"
+
 const (
 	// avoFinalizer is added to the VpcEndpoint object to prevent its deletion until all AWS resources
 	// have been cleaned up
 	avoFinalizer   = "vpcendpoint.avo.openshift.io/finalizer"
 	ControllerName = "VpcEndpoint"
+
+	// defaultAssumeRoleSessionName is the session name used when assuming .spec.assumeRoleArn if
+	// .spec.assumeRoleSessionName is not specified
+	defaultAssumeRoleSessionName = "aws-vpce-operator"
+
+	// unmanagedSecurityGroupRulesAnnotation is a comma-separated list of security group rule ids, or "*" for all of
+	// them, that AVO must not revoke when .spec.securityGroup.strict is true, e.g. rules managed outside of AVO
+	unmanagedSecurityGroupRulesAnnotation = "vpcendpoint.avo.openshift.io/unmanaged-security-group-rules"
+
+	// securityGroupModeUserProvided is the .spec.securityGroup.mode where AVO does not create its own security group
+	securityGroupModeUserProvided = "UserProvided"
+
+	// .spec.recreatePolicy values, OnRejected is the default
+	recreatePolicyNever    = "Never"
+	recreatePolicyOnFailed = "OnFailed"
+
+	// Defaults for .spec.recreateBackoff
+	defaultRecreateMaxAttempts  = 5
+	defaultRecreateInitialDelay = time.Minute
+	defaultRecreateMaxDelay     = time.Hour
+
+	// recreateRequeueDelay is how long to wait after deleting a VPC Endpoint before recreating it
+	recreateRequeueDelay = time.Second * 30
+
+	// route53ChangeRequeueDelay is how long to wait before checking again whether a change to Route53 records has
+	// propagated
+	route53ChangeRequeueDelay = time.Second * 10
+
+	// subnetReplacementRequeueDelay is how long to wait for the network interfaces of subnets removed from a VPC
+	// Endpoint to be deleted before adding the subnets back
+	subnetReplacementRequeueDelay = time.Second * 30
+
+	// migrationRequeueDelay is how long to wait for the VPC Endpoint in the previous VPC to be deleted before
+	// deleting its security group
+	migrationRequeueDelay = time.Second * 30
+
+	// Defaults for .spec.vpc.rebalance
+	defaultRebalanceSkewThreshold       = 2
+	defaultRebalanceMaxMovesPerInterval = 1
+	defaultRebalanceInterval            = time.Hour
+
+	// defaultVpcEndpointQuota is the default AWS quota of Interface VPC Endpoints per VPC, used by the QuotaHeadroom
+	// placement strategy unless .spec.vpc.endpointQuota is specified
+	defaultVpcEndpointQuota = 50
+
+	// vpcQuotaCooldown is how long a VPC that rejected a VPC Endpoint for exceeding its VPC Endpoint quota is
+	// considered full and skipped when placing load balanced VPC Endpoints
+	vpcQuotaCooldown = time.Minute * 30
+
+	// .spec.vpc.subnetSelectionPolicy values, LowestId is the default
+	subnetSelectionPolicyMostFreeIps  = "MostFreeIps"
+	subnetSelectionPolicyPreferredTag = "PreferredTag"
+
+	// defaultRecordTTL is the TTL of CNAME records unless .spec.customDns.route53PrivateHostedZone.record.ttl is
+	// specified
+	defaultRecordTTL = 300
+
+	// zoneApexHostname is the .spec.customDns.route53PrivateHostedZone.record.hostname of a record at the zone apex
+	zoneApexHostname = "@"
+
+	// ownershipRecordPrefix is the first label of the TXT record that records the owner of a Route53 record, since a
+	// CNAME record can't coexist with a TXT record of the same name. ownershipWildcardRecordPrefix replaces the "*" of
+	// wildcard records instead, which must be the first label.
+	ownershipRecordPrefix         = "_avo-owner"
+	ownershipWildcardRecordPrefix = "_avo-owner-wildcard"
+
+	// Keys of the value of ownership TXT records, similar to external-dns' TXT registry
+	ownershipHeritage    = "heritage=aws-vpce-operator"
+	ownershipOwnerKey    = "aws-vpce-operator/owner"
+	ownershipResourceKey = "aws-vpce-operator/resource"
 )
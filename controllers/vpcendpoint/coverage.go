@@ -0,0 +1,66 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"fmt"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// validateAZCoverage sets the AZCoverage condition according to .spec.vpc.minAvailabilityZones. If the VPC Endpoint's
+// subnets are in fewer Availability Zones, it returns a message explaining why the VPC Endpoint is degraded.
+// Gateway VPC Endpoints have no subnets, so this is a no-op for them.
+func (r *VpcEndpointReconciler) validateAZCoverage(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) (string, error) {
+	minAZs := int(resource.Spec.Vpc.MinAvailabilityZones)
+	if minAZs == 0 || vpcEndpointType(resource) == ec2Types.VpcEndpointTypeGateway {
+		meta.RemoveStatusCondition(&resource.Status.Conditions, avov1alpha2.AZCoverageCondition)
+		return "", nil
+	}
+
+	zoneIds, err := r.awsClient.SubnetAvailabilityZoneIds(ctx, vpce.SubnetIds)
+	if err != nil {
+		return "", err
+	}
+
+	if len(zoneIds) < minAZs {
+		message := fmt.Sprintf("VPC Endpoint has subnets in %d Availability Zone(s) %v, fewer than the minimum of %d",
+			len(zoneIds), zoneIds, minAZs)
+		r.log.V(0).Info("VPC Endpoint is degraded", "reason", message)
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AZCoverageCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Degraded",
+			Message: message,
+		})
+
+		return message, nil
+	}
+
+	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+		Type:    avov1alpha2.AZCoverageCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Covered",
+		Message: fmt.Sprintf("VPC Endpoint has subnets in %d Availability Zone(s) %v", len(zoneIds), zoneIds),
+	})
+
+	return "", nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr/testr"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVpcEndpointReconciler_validateAZCoverage(t *testing.T) {
+	subnets := []*ec2Types.Subnet{
+		{SubnetId: aws.String("subnet-a"), AvailabilityZoneId: aws.String("use1-az1")},
+		{SubnetId: aws.String("subnet-b"), AvailabilityZoneId: aws.String("use1-az2")},
+	}
+
+	tests := []struct {
+		name           string
+		subnetIds      []string
+		endpointType   string
+		min            int32
+		expectDegraded bool
+		expectedStatus metav1.ConditionStatus
+	}{
+		{
+			name:      "no minimum",
+			subnetIds: []string{"subnet-a"},
+		},
+		{
+			name:           "sufficient coverage",
+			subnetIds:      []string{"subnet-a", "subnet-b"},
+			min:            2,
+			expectedStatus: metav1.ConditionTrue,
+		},
+		{
+			name:           "single AZ",
+			subnetIds:      []string{"subnet-a"},
+			min:            2,
+			expectDegraded: true,
+			expectedStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "gateway endpoint",
+			endpointType: string(ec2Types.VpcEndpointTypeGateway),
+			min:          2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &VpcEndpointReconciler{
+				log:       testr.New(t),
+				awsClient: aws_client.NewAwsClientWithServiceClients(&aws_client.MockedEC2{Subnets: subnets}, &aws_client.MockedRoute53{}),
+			}
+			resource := &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Type: test.endpointType,
+					Vpc:  avov1alpha2.Vpc{MinAvailabilityZones: test.min},
+				},
+			}
+
+			degraded, err := r.validateAZCoverage(context.TODO(), &ec2Types.VpcEndpoint{SubnetIds: test.subnetIds}, resource)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectDegraded, degraded != "")
+
+			cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AZCoverageCondition)
+			if test.expectedStatus == "" {
+				assert.Nil(t, cond)
+			} else if assert.NotNil(t, cond) {
+				assert.Equal(t, test.expectedStatus, cond.Status)
+			}
+		})
+	}
+}
@@ -20,7 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -38,6 +41,7 @@ import (
 	"github.com/openshift/aws-vpce-operator/pkg/util"
 	hyperv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -103,14 +107,22 @@ func (r *VpcEndpointReconciler) parseClusterInfo(ctx context.Context, vpce *avov
 		r.log.V(1).Info("Parsed region from infrastructure", "region", region)
 	}
 
-	if vpce.Spec.AWSCredentialOverrideRef != nil {
+	switch {
+	case vpce.Spec.AssumeRoleArn != "":
+		// Assume the provided role for this specific vpcendpoint
+		cfg, err := r.assumeRole(ctx, vpce)
+		if err != nil {
+			return err
+		}
+		r.awsClient = aws_client.NewAwsClient(cfg)
+	case vpce.Spec.AWSCredentialOverrideRef != nil:
 		// Use the provided override credentials for this specific vpcendpoint
 		cfg, err := secrets.ParseAWSCredentialOverride(ctx, r.APIReader, r.clusterInfo.region, vpce.Spec.AWSCredentialOverrideRef)
 		if err != nil {
 			return err
 		}
 		r.awsClient = aws_client.NewAwsClient(cfg)
-	} else {
+	default:
 		// Load the default AWS credentials that are available to the controller
 		if refreshAWSSession {
 			cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(r.clusterInfo.region))
@@ -121,66 +133,173 @@ func (r *VpcEndpointReconciler) parseClusterInfo(ctx context.Context, vpce *avov
 		}
 	}
 
-	// If .status.vpcId is empty, we need to populate it
-	if vpce.Status.VPCId == "" {
-		var vpcId string
+	// Cleaning up a VpcEndpoint that is being deleted only relies on .status, so there's no VPC to select
+	if !vpce.DeletionTimestamp.IsZero() {
+		return nil
+	}
 
-		switch {
-		case len(vpce.Spec.Vpc.Tags) > 0:
-			ids, err := r.awsClient.FilterVpcIdsByTags(ctx, vpce.Spec.Vpc.Tags)
-			if err != nil {
-				return fmt.Errorf("failed to select a VPC to place a VPC Endpoint in: %w", err)
-			}
+	// Only re-evaluate .spec.vpc when the VpcEndpoint changed since .status.vpcId was selected to avoid querying AWS
+	// on every reconcile
+	if vpce.Status.VPCId != "" && vpce.Status.VPCSelectionGeneration == vpce.Generation {
+		return nil
+	}
 
-			r.log.V(1).Info("Found candidate VPCs by tag", "ids", ids)
-			v, err := r.awsClient.SelectVPCForVPCEndpoint(ctx, ids...)
-			if err != nil {
-				return fmt.Errorf("failed to select a VPC to place a VPC Endpoint in: %w", err)
-			}
-			vpcId = v
-			r.log.V(1).Info("Selecting vpc id by tags", "vpcId", vpcId)
-		case len(vpce.Spec.Vpc.Ids) > 0:
-			v, err := r.awsClient.SelectVPCForVPCEndpoint(ctx, vpce.Spec.Vpc.Ids...)
-			if err != nil {
-				return fmt.Errorf("failed to select a VPC to place a VPC Endpoint in: %w", err)
-			}
-			vpcId = v
-			r.log.V(1).Info("Selecting vpc id", "vpcId", vpcId)
-		case vpce.Spec.Vpc.AutoDiscoverSubnets:
-			resp, err := r.awsClient.AutodiscoverPrivateSubnets(ctx, r.clusterInfo.clusterTag, vpce.Spec.Vpc.SubnetTags...)
-			if err != nil {
-				return fmt.Errorf("unable to autodiscover subnets: %w", err)
-			}
+	// If .status.vpcId is empty, we need to populate it. If it no longer matches .spec.vpc, the VpcEndpoint needs to
+	// be migrated to a newly selected VPC.
+	vpcIds, err := r.candidateVpcIds(ctx, vpce)
+	if err != nil {
+		return err
+	}
 
-			subnets := make([]string, len(resp))
-			for i := range resp {
-				subnets[i] = *resp[i].SubnetId
-			}
+	if vpce.Status.VPCId != "" && slices.Contains(vpcIds, vpce.Status.VPCId) {
+		vpce.Status.VPCSelectionGeneration = vpce.Generation
+		if err := r.Status().Update(ctx, vpce); err != nil {
+			return fmt.Errorf("failed to update status: %v", err)
+		}
 
-			v, err := r.awsClient.GetVPCId(ctx, subnets)
-			if err != nil {
-				return err
-			}
-			vpcId = v
-			r.log.V(1).Info("Found vpc id:", "vpcId", vpcId)
-		default:
-			v, err := r.awsClient.GetVPCId(ctx, vpce.Spec.Vpc.SubnetIds)
-			if err != nil {
-				return err
-			}
-			vpcId = v
-			r.log.V(1).Info("Found vpc id:", "vpcId", vpcId)
+		return nil
+	}
+
+	if vpce.Status.Migration != nil {
+		// Finish the current migration before starting another one, .status.migration only tracks one previous VPC
+		r.log.V(0).Info("Waiting for the current VPC migration to complete", "from", vpce.Status.Migration.VPCId, "to", vpce.Status.VPCId)
+		return nil
+	}
+
+	placement := aws_client.VPCPlacement{
+		VpcId:  vpcIds[0],
+		Reason: fmt.Sprintf("%s is the only VPC matching .spec.vpc", vpcIds[0]),
+	}
+	if len(vpcIds) > 1 {
+		placement, err = r.placeVpcEndpoint(ctx, vpce, vpcIds)
+		if err != nil {
+			return fmt.Errorf("failed to select a VPC to place a VPC Endpoint in: %w", err)
 		}
+	}
 
+	vpcId := placement.VpcId
+	vpce.Status.PlacementReason = placement.Reason
+	vpce.Status.VPCSelectionGeneration = vpce.Generation
+	if vpce.Status.VPCId == "" {
+		r.log.V(1).Info("Selecting vpc id", "vpcId", vpcId, "reason", placement.Reason)
 		vpce.Status.VPCId = vpcId
-		if err := r.Status().Update(ctx, vpce); err != nil {
-			return fmt.Errorf("failed to update status: %v", err)
-		}
+	} else {
+		r.log.V(0).Info("VPC selection changed, migrating VPC Endpoint", "from", vpce.Status.VPCId, "to", vpcId)
+		r.Recorder.Eventf(vpce, corev1.EventTypeNormal, "Migrating", "Migrating VPC Endpoint from %s to %s", vpce.Status.VPCId, vpcId)
+		startVpcMigration(vpce, vpcId, "Migrating")
+	}
+
+	if err := r.Status().Update(ctx, vpce); err != nil {
+		return fmt.Errorf("failed to update status: %v", err)
 	}
 
 	return nil
 }
 
+// placeVpcEndpoint selects one of vpcIds to place the VPC Endpoint in according to .spec.vpc.placementStrategy
+func (r *VpcEndpointReconciler) placeVpcEndpoint(ctx context.Context, vpce *avov1alpha2.VpcEndpoint, vpcIds []string) (aws_client.VPCPlacement, error) {
+	var strategy aws_client.VPCPlacementStrategy
+	switch vpce.Spec.Vpc.PlacementStrategy {
+	case aws_client.PlacementStrategyRoundRobin:
+		strategy = r.roundRobin
+	case aws_client.PlacementStrategyAvailabilityZoneOverlap:
+		strategy = aws_client.AvailabilityZoneOverlapStrategy{}
+	case aws_client.PlacementStrategyQuotaHeadroom:
+		strategy = aws_client.QuotaHeadroomStrategy{}
+	default:
+		strategy = aws_client.LeastUsedStrategy{}
+	}
+
+	// Skip VPCs that recently reached their VPC Endpoint quota
+	available, _ := r.vpcQuotaCooldowns.available(vpcIds, time.Now())
+	if len(available) == 0 {
+		return aws_client.VPCPlacement{}, fmt.Errorf("all VPCs %v recently reached their VPC Endpoint quota", vpcIds)
+	}
+	vpcIds = available
+
+	quota := defaultVpcEndpointQuota
+	if vpce.Spec.Vpc.EndpointQuota > 0 {
+		quota = int(vpce.Spec.Vpc.EndpointQuota)
+	}
+
+	return strategy.Place(ctx, r.awsClient, aws_client.VPCPlacementRequest{
+		VpcIds:              vpcIds,
+		ServiceName:         vpce.Status.VPCEndpointServiceName,
+		SubnetTags:          vpce.Spec.Vpc.SubnetTags,
+		SubnetDiscoveryMode: vpce.Spec.Vpc.SubnetDiscoveryMode,
+		MinFreeIpAddresses:  int(vpce.Spec.Vpc.MinFreeIpAddresses),
+		Quota:               quota,
+	})
+}
+
+// candidateVpcIds returns the ids of the VPCs that match .spec.vpc
+func (r *VpcEndpointReconciler) candidateVpcIds(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) ([]string, error) {
+	switch {
+	case len(vpce.Spec.Vpc.Tags) > 0:
+		ids, err := r.awsClient.FilterVpcIdsByTags(ctx, vpce.Spec.Vpc.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select a VPC to place a VPC Endpoint in: %w", err)
+		}
+		r.log.V(1).Info("Found candidate VPCs by tag", "ids", ids)
+
+		return ids, nil
+	case len(vpce.Spec.Vpc.Ids) > 0:
+		return vpce.Spec.Vpc.Ids, nil
+	case vpce.Spec.Vpc.AutoDiscoverSubnets:
+		resp, err := r.awsClient.DiscoverPrivateSubnets(ctx, vpce.Spec.Vpc.SubnetDiscoveryMode, r.clusterInfo.clusterTag, nil, vpce.Spec.Vpc.SubnetTags...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to autodiscover subnets: %w", err)
+		}
+
+		subnets := make([]string, len(resp))
+		for i := range resp {
+			subnets[i] = *resp[i].SubnetId
+		}
+
+		vpcId, err := r.awsClient.GetVPCId(ctx, subnets)
+		if err != nil {
+			return nil, err
+		}
+		r.log.V(1).Info("Found vpc id:", "vpcId", vpcId)
+
+		return []string{vpcId}, nil
+	case vpcEndpointType(vpce) == ec2Types.VpcEndpointTypeGateway && len(vpce.Spec.Vpc.RouteTableIds) > 0:
+		vpcId, err := r.awsClient.GetRouteTablesVPCId(ctx, vpce.Spec.Vpc.RouteTableIds)
+		if err != nil {
+			return nil, err
+		}
+		r.log.V(1).Info("Found vpc id from route tables:", "vpcId", vpcId)
+
+		return []string{vpcId}, nil
+	case vpcEndpointType(vpce) == ec2Types.VpcEndpointTypeGateway && len(vpce.Spec.Vpc.RouteTableTags) > 0 && len(vpce.Spec.Vpc.SubnetIds) == 0:
+		routeTables, err := r.awsClient.FilterRouteTablesByTags(ctx, "", vpce.Spec.Vpc.RouteTableTags...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select a VPC to place a VPC Endpoint in: %w", err)
+		}
+
+		var ids []string
+		for _, rt := range routeTables {
+			if !slices.Contains(ids, aws.ToString(rt.VpcId)) {
+				ids = append(ids, aws.ToString(rt.VpcId))
+			}
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("no route tables found with tags: %v", vpce.Spec.Vpc.RouteTableTags)
+		}
+		r.log.V(1).Info("Found candidate VPCs from route tables", "ids", ids)
+
+		return ids, nil
+	default:
+		vpcId, err := r.awsClient.GetVPCId(ctx, vpce.Spec.Vpc.SubnetIds)
+		if err != nil {
+			return nil, err
+		}
+		r.log.V(1).Info("Found vpc id:", "vpcId", vpcId)
+
+		return []string{vpcId}, nil
+	}
+}
+
 // awsUnauthorizedOperationMetricHandler determines if an error is an AWS UnauthorizedOperation or AccessDenied and
 // increments the aws_vpce_operator_unauthorized_operation_total metric accordingly
 func awsUnauthorizedOperationMetricHandler(err error) {
@@ -255,7 +374,7 @@ func (r *VpcEndpointReconciler) findOrCreateSecurityGroup(ctx context.Context, r
 		}
 
 		r.log.V(1).Info("Searching for security group by tags")
-		resp, err = r.awsClient.FilterSecurityGroupByDefaultTags(ctx, resource.Status.InfraId, sgName)
+		resp, err = r.awsClient.FilterSecurityGroupByDefaultTags(ctx, resource.Status.InfraId, sgName, resource.Status.VPCId)
 		if err != nil {
 			return nil, err
 		}
@@ -333,7 +452,7 @@ func (r *VpcEndpointReconciler) createMissingSecurityGroupTags(ctx context.Conte
 
 // generateMissingSecurityGroupRules ensures that the cluster's worker and master security groups are allowed ingresses
 // to the VPC Endpoint security group as well as and other configured rules from the CR.
-// It will not remove an extra security group rules and only create missing ones.
+// It will not remove an extra security group rules and only create missing ones, see revokeUndesiredSecurityGroupRules.
 func (r *VpcEndpointReconciler) generateMissingSecurityGroupRules(ctx context.Context, sg *ec2Types.SecurityGroup, resource *avov1alpha2.VpcEndpoint) (
 	*ec2.AuthorizeSecurityGroupIngressInput, *ec2.AuthorizeSecurityGroupEgressInput, error) {
 	if sg == nil || resource == nil {
@@ -350,9 +469,9 @@ func (r *VpcEndpointReconciler) generateMissingSecurityGroupRules(ctx context.Co
 		return nil, nil, err
 	}
 
-	sourceSgIds := make([]*string, len(sourceSgResp.SecurityGroups))
+	sourceSgIds := make([]string, len(sourceSgResp.SecurityGroups))
 	for i := range sourceSgResp.SecurityGroups {
-		sourceSgIds[i] = sourceSgResp.SecurityGroups[i].GroupId
+		sourceSgIds[i] = *sourceSgResp.SecurityGroups[i].GroupId
 	}
 
 	if len(sourceSgIds) == 0 {
@@ -366,118 +485,14 @@ func (r *VpcEndpointReconciler) generateMissingSecurityGroupRules(ctx context.Co
 	)
 
 	for i := range resource.Spec.SecurityGroup.IngressRules {
-		switch {
-		case resource.Spec.SecurityGroup.IngressRules[i].CidrIp != "":
-			create := true
-			for _, rule := range rulesResp.SecurityGroupRules {
-				if avoAndAwsSecurityGroupRuleCandidate(false, resource.Spec.SecurityGroup.IngressRules[i], rule) {
-					// If we find a rule with the correct protocol, fromPort, and toPort, check CidrIP
-					if rule.CidrIpv4 != nil && resource.Spec.SecurityGroup.IngressRules[i].CidrIp == *rule.CidrIpv4 {
-						create = false
-						break
-					}
-				}
-			}
-
-			if create {
-				ingressRules = append(ingressRules, ec2Types.IpPermission{
-					IpProtocol: aws.String(resource.Spec.SecurityGroup.IngressRules[i].Protocol),
-					FromPort:   aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].FromPort),
-					ToPort:     aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].ToPort),
-					IpRanges: []ec2Types.IpRange{
-						{
-							CidrIp: aws.String(resource.Spec.SecurityGroup.IngressRules[i].CidrIp),
-						},
-					},
-				})
-			}
-		case len(sourceSgIds) > 0:
-			for _, sourceSgId := range sourceSgIds {
-				create := true
-				for _, rule := range rulesResp.SecurityGroupRules {
-					if avoAndAwsSecurityGroupRuleCandidate(false, resource.Spec.SecurityGroup.IngressRules[i], rule) {
-						if rule.ReferencedGroupInfo == nil {
-							continue
-						}
-						if *rule.ReferencedGroupInfo.GroupId == *sourceSgId {
-							// If we find a rule with the correct protocol, fromPort, and toPort, check the source security group
-							create = false
-							break
-						}
-					}
-				}
-
-				if create {
-					ingressRules = append(ingressRules, ec2Types.IpPermission{
-						IpProtocol: aws.String(resource.Spec.SecurityGroup.IngressRules[i].Protocol),
-						FromPort:   aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].FromPort),
-						ToPort:     aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].ToPort),
-						UserIdGroupPairs: []ec2Types.UserIdGroupPair{
-							{
-								GroupId: sourceSgId,
-							},
-						},
-					})
-				}
-			}
+		if permission := missingPermission(false, resource.Spec.SecurityGroup.IngressRules[i], rulesResp.SecurityGroupRules, sourceSgIds); permission != nil {
+			ingressRules = append(ingressRules, *permission)
 		}
 	}
 
 	for i := range resource.Spec.SecurityGroup.EgressRules {
-		switch {
-		case resource.Spec.SecurityGroup.EgressRules[i].CidrIp != "":
-			create := true
-			for _, rule := range rulesResp.SecurityGroupRules {
-				if avoAndAwsSecurityGroupRuleCandidate(true, resource.Spec.SecurityGroup.IngressRules[i], rule) {
-					// If we find a rule with the correct protocol, fromPort, and toPort, check CidrIP
-					if rule.CidrIpv4 != nil && resource.Spec.SecurityGroup.IngressRules[i].CidrIp == *rule.CidrIpv4 {
-						create = false
-						break
-					}
-				}
-			}
-
-			if create {
-				egressRules = append(egressRules, ec2Types.IpPermission{
-					IpProtocol: aws.String(resource.Spec.SecurityGroup.EgressRules[i].Protocol),
-					FromPort:   aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].FromPort),
-					ToPort:     aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].ToPort),
-					IpRanges: []ec2Types.IpRange{
-						{
-							CidrIp: aws.String(resource.Spec.SecurityGroup.EgressRules[i].CidrIp),
-						},
-					},
-				})
-			}
-		case len(sourceSgIds) > 0:
-			for _, sourceSgId := range sourceSgIds {
-				create := true
-				for _, rule := range rulesResp.SecurityGroupRules {
-					if avoAndAwsSecurityGroupRuleCandidate(true, resource.Spec.SecurityGroup.IngressRules[i], rule) {
-						if rule.ReferencedGroupInfo == nil {
-							continue
-						}
-						if *rule.ReferencedGroupInfo.GroupId == *sourceSgId {
-							// If we find a rule with the correct protocol, fromPort, and toPort, check the source security group
-							create = false
-							break
-						}
-					}
-				}
-
-				if create {
-					egressRules = append(egressRules, ec2Types.IpPermission{
-						IpProtocol: aws.String(resource.Spec.SecurityGroup.EgressRules[i].Protocol),
-						FromPort:   aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].FromPort),
-						ToPort:     aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].ToPort),
-						UserIdGroupPairs: []ec2Types.UserIdGroupPair{
-							{
-								GroupId: sourceSgId,
-							},
-						},
-					})
-				}
-			}
+		if permission := missingPermission(true, resource.Spec.SecurityGroup.EgressRules[i], rulesResp.SecurityGroupRules, sourceSgIds); permission != nil {
+			egressRules = append(egressRules, *permission)
 		}
 	}
 
@@ -539,8 +554,278 @@ func (r *VpcEndpointReconciler) generateMissingSecurityGroupRules(ctx context.Co
 	return ingressInput, egressInput, nil
 }
 
+// securityGroupRulePeer is a single source (ingress) or destination (egress) of a security group rule.
+// Exactly one of cidrIpv4, cidrIpv6, groupId, or prefixListId is set, userId is optionally set alongside groupId.
+type securityGroupRulePeer struct {
+	cidrIpv4     string
+	cidrIpv6     string
+	userId       string
+	groupId      string
+	prefixListId string
+}
+
+// securityGroupRulePeers returns the peers allowed by an avov1alpha2 SecurityGroupRule. If the rule does not specify
+// any CIDRs, security groups, or prefix lists, the source security groups are returned instead.
+func securityGroupRulePeers(avoRule avov1alpha2.SecurityGroupRule, sourceSgIds []string) []securityGroupRulePeer {
+	var peers []securityGroupRulePeer
+	add := func(peer securityGroupRulePeer) {
+		if !slices.Contains(peers, peer) {
+			peers = append(peers, peer)
+		}
+	}
+
+	for _, cidr := range append([]string{avoRule.CidrIp, avoRule.CidrIpv6}, avoRule.Cidrs...) {
+		switch {
+		case cidr == "":
+			continue
+		case strings.Contains(cidr, ":"):
+			add(securityGroupRulePeer{cidrIpv6: cidr})
+		default:
+			add(securityGroupRulePeer{cidrIpv4: cidr})
+		}
+	}
+
+	for _, id := range avoRule.SourceSecurityGroupIds {
+		// Security groups in other AWS accounts are specified as userId/groupId
+		if userId, groupId, found := strings.Cut(id, "/"); found {
+			add(securityGroupRulePeer{userId: userId, groupId: groupId})
+		} else {
+			add(securityGroupRulePeer{groupId: id})
+		}
+	}
+
+	for _, id := range avoRule.PrefixListIds {
+		add(securityGroupRulePeer{prefixListId: id})
+	}
+
+	if len(peers) == 0 {
+		for _, id := range sourceSgIds {
+			add(securityGroupRulePeer{groupId: id})
+		}
+	}
+
+	return peers
+}
+
+// matches checks if an EC2 SecurityGroupRule allows the peer, not considering its protocol or ports
+func (p securityGroupRulePeer) matches(awsRule ec2Types.SecurityGroupRule) bool {
+	switch {
+	case p.cidrIpv4 != "":
+		return aws.ToString(awsRule.CidrIpv4) == p.cidrIpv4
+	case p.cidrIpv6 != "":
+		return aws.ToString(awsRule.CidrIpv6) == p.cidrIpv6
+	case p.prefixListId != "":
+		return aws.ToString(awsRule.PrefixListId) == p.prefixListId
+	case awsRule.ReferencedGroupInfo == nil:
+		return false
+	default:
+		return aws.ToString(awsRule.ReferencedGroupInfo.GroupId) == p.groupId &&
+			(p.userId == "" || aws.ToString(awsRule.ReferencedGroupInfo.UserId) == p.userId)
+	}
+}
+
+// missingPermission returns an IpPermission containing the peers of an avov1alpha2 SecurityGroupRule that are not yet
+// allowed by any of the EC2 SecurityGroupRules, or nil if none are missing.
+func missingPermission(isEgress bool, avoRule avov1alpha2.SecurityGroupRule, awsRules []ec2Types.SecurityGroupRule, sourceSgIds []string) *ec2Types.IpPermission {
+	var description *string
+	if avoRule.Description != "" {
+		description = aws.String(avoRule.Description)
+	}
+
+	permission := &ec2Types.IpPermission{
+		IpProtocol: aws.String(avoRule.Protocol),
+		FromPort:   aws.Int32(avoRule.FromPort),
+		ToPort:     aws.Int32(avoRule.ToPort),
+	}
+
+	for _, peer := range securityGroupRulePeers(avoRule, sourceSgIds) {
+		if slices.ContainsFunc(awsRules, func(awsRule ec2Types.SecurityGroupRule) bool {
+			// If we find a rule with the correct protocol, fromPort, and toPort, check the peer
+			return avoAndAwsSecurityGroupRuleCandidate(isEgress, avoRule, awsRule) && peer.matches(awsRule)
+		}) {
+			continue
+		}
+
+		switch {
+		case peer.cidrIpv4 != "":
+			permission.IpRanges = append(permission.IpRanges, ec2Types.IpRange{
+				CidrIp:      aws.String(peer.cidrIpv4),
+				Description: description,
+			})
+		case peer.cidrIpv6 != "":
+			permission.Ipv6Ranges = append(permission.Ipv6Ranges, ec2Types.Ipv6Range{
+				CidrIpv6:    aws.String(peer.cidrIpv6),
+				Description: description,
+			})
+		case peer.prefixListId != "":
+			permission.PrefixListIds = append(permission.PrefixListIds, ec2Types.PrefixListId{
+				PrefixListId: aws.String(peer.prefixListId),
+				Description:  description,
+			})
+		default:
+			pair := ec2Types.UserIdGroupPair{
+				GroupId:     aws.String(peer.groupId),
+				Description: description,
+			}
+			if peer.userId != "" {
+				pair.UserId = aws.String(peer.userId)
+			}
+			permission.UserIdGroupPairs = append(permission.UserIdGroupPairs, pair)
+		}
+	}
+
+	if len(permission.IpRanges) == 0 && len(permission.Ipv6Ranges) == 0 &&
+		len(permission.PrefixListIds) == 0 && len(permission.UserIdGroupPairs) == 0 {
+		return nil
+	}
+
+	return permission
+}
+
+// revokeUndesiredSecurityGroupRules revokes any rules from the security group that are not specified by the CR when
+// .spec.securityGroup.strict is true, except for rules listed in the unmanagedSecurityGroupRulesAnnotation.
+// If the cluster's security groups can't be found, rules that may allow them are not revoked and true is returned.
+func (r *VpcEndpointReconciler) revokeUndesiredSecurityGroupRules(ctx context.Context, sg *ec2Types.SecurityGroup, resource *avov1alpha2.VpcEndpoint) (bool, error) {
+	if sg == nil || resource == nil {
+		return false, fmt.Errorf("security group and resource must not be nil")
+	}
+
+	if !resource.Spec.SecurityGroup.Strict {
+		return false, nil
+	}
+
+	rulesResp, err := r.awsClient.DescribeSecurityGroupRules(ctx, *sg.GroupId)
+	if err != nil {
+		return false, err
+	}
+
+	sourceSgResp, err := r.awsClient.FilterClusterNodeSecurityGroupsByDefaultTags(ctx, resource.Status.InfraId)
+	if err != nil {
+		return false, err
+	}
+
+	sourceSgIds := make([]string, len(sourceSgResp.SecurityGroups))
+	for i := range sourceSgResp.SecurityGroups {
+		sourceSgIds[i] = *sourceSgResp.SecurityGroups[i].GroupId
+	}
+
+	clusterSgsNotFound := len(sourceSgIds) == 0
+	if clusterSgsNotFound {
+		// Otherwise every rule allowing the cluster's security groups would be revoked
+		r.log.V(0).Info("Unable to find source security groups, not revoking security group rules that may allow them")
+	}
+
+	undesired := undesiredSecurityGroupRules(resource, rulesResp.SecurityGroupRules, sourceSgIds)
+	if len(undesired) == 0 {
+		return clusterSgsNotFound, nil
+	}
+
+	var (
+		ingressRuleIds []string
+		egressRuleIds  []string
+		descriptions   []string
+	)
+
+	for _, rule := range undesired {
+		if aws.ToBool(rule.IsEgress) {
+			egressRuleIds = append(egressRuleIds, *rule.SecurityGroupRuleId)
+		} else {
+			ingressRuleIds = append(ingressRuleIds, *rule.SecurityGroupRuleId)
+		}
+		descriptions = append(descriptions, describeSecurityGroupRule(rule))
+	}
+
+	r.log.V(0).Info("Revoking security group rules", "securityGroup", *sg.GroupId, "rules", descriptions)
+	if err := r.awsClient.RevokeSecurityGroupRules(ctx, *sg.GroupId, ingressRuleIds, egressRuleIds); err != nil {
+		return clusterSgsNotFound, fmt.Errorf("failed to revoke security group rules: %w", err)
+	}
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Revoked", "Revoked security group rules from %s: %s", *sg.GroupId, strings.Join(descriptions, ", "))
+
+	return clusterSgsNotFound, nil
+}
+
+// undesiredSecurityGroupRules returns the EC2 SecurityGroupRules that are not specified by the CR's
+// .spec.securityGroup, skipping any listed in the unmanagedSecurityGroupRulesAnnotation. If sourceSgIds is empty, rules
+// referencing a security group that may have been created for a rule allowing the cluster's security groups are
+// skipped as well.
+func undesiredSecurityGroupRules(resource *avov1alpha2.VpcEndpoint, awsRules []ec2Types.SecurityGroupRule, sourceSgIds []string) []ec2Types.SecurityGroupRule {
+	unmanaged := map[string]bool{}
+	for _, id := range strings.Split(resource.Annotations[unmanagedSecurityGroupRulesAnnotation], ",") {
+		unmanaged[strings.TrimSpace(id)] = true
+	}
+
+	if unmanaged["*"] {
+		return nil
+	}
+
+	var undesired []ec2Types.SecurityGroupRule
+	for _, awsRule := range awsRules {
+		if awsRule.SecurityGroupRuleId == nil || unmanaged[*awsRule.SecurityGroupRuleId] {
+			continue
+		}
+
+		isEgress := aws.ToBool(awsRule.IsEgress)
+		avoRules := resource.Spec.SecurityGroup.IngressRules
+		if isEgress {
+			avoRules = resource.Spec.SecurityGroup.EgressRules
+		}
+
+		if !slices.ContainsFunc(avoRules, func(avoRule avov1alpha2.SecurityGroupRule) bool {
+			return securityGroupRuleDesired(isEgress, avoRule, awsRule, sourceSgIds) ||
+				len(sourceSgIds) == 0 && clusterSecurityGroupRuleCandidate(isEgress, avoRule, awsRule)
+		}) {
+			undesired = append(undesired, awsRule)
+		}
+	}
+
+	return undesired
+}
+
+// securityGroupRuleDesired checks if an EC2 SecurityGroupRule is one of the rules generated for an avov1alpha2
+// SecurityGroupRule, i.e. it allows one of its peers, see securityGroupRulePeers.
+func securityGroupRuleDesired(isEgress bool, avoRule avov1alpha2.SecurityGroupRule, awsRule ec2Types.SecurityGroupRule, sourceSgIds []string) bool {
+	if !avoAndAwsSecurityGroupRuleCandidate(isEgress, avoRule, awsRule) {
+		return false
+	}
+
+	return slices.ContainsFunc(securityGroupRulePeers(avoRule, sourceSgIds), func(peer securityGroupRulePeer) bool {
+		return peer.matches(awsRule)
+	})
+}
+
+// clusterSecurityGroupRuleCandidate checks if an EC2 SecurityGroupRule could be one of the rules generated for an
+// avov1alpha2 SecurityGroupRule that allows the cluster's security groups, i.e. one without any explicit peers
+func clusterSecurityGroupRuleCandidate(isEgress bool, avoRule avov1alpha2.SecurityGroupRule, awsRule ec2Types.SecurityGroupRule) bool {
+	return awsRule.ReferencedGroupInfo != nil && len(securityGroupRulePeers(avoRule, nil)) == 0 &&
+		avoAndAwsSecurityGroupRuleCandidate(isEgress, avoRule, awsRule)
+}
+
+// describeSecurityGroupRule returns a short human-readable description of an EC2 SecurityGroupRule, e.g.
+// "sgr-12345 (ingress tcp 443-443 10.0.0.0/16)"
+func describeSecurityGroupRule(rule ec2Types.SecurityGroupRule) string {
+	direction := "ingress"
+	if aws.ToBool(rule.IsEgress) {
+		direction = "egress"
+	}
+
+	var peer string
+	switch {
+	case rule.CidrIpv4 != nil:
+		peer = *rule.CidrIpv4
+	case rule.CidrIpv6 != nil:
+		peer = *rule.CidrIpv6
+	case rule.ReferencedGroupInfo != nil:
+		peer = aws.ToString(rule.ReferencedGroupInfo.GroupId)
+	case rule.PrefixListId != nil:
+		peer = *rule.PrefixListId
+	}
+
+	return fmt.Sprintf("%s (%s %s %d-%d %s)", aws.ToString(rule.SecurityGroupRuleId), direction, aws.ToString(rule.IpProtocol),
+		aws.ToInt32(rule.FromPort), aws.ToInt32(rule.ToPort), peer)
+}
+
 // avoAndAwsSecurityGroupRuleCandidate checks if an avov1alpha2 SecurityGroupRule and an EC2 SecurityGroupRule
-// are mostly similar. It does not perform checks on fields such as CidrIP and SourceSecurityGroup.
+// are mostly similar. It does not perform checks on its peers such as CIDRs and source security groups.
 func avoAndAwsSecurityGroupRuleCandidate(isEgress bool, avoRule avov1alpha2.SecurityGroupRule, awsRule ec2Types.SecurityGroupRule) bool {
 	if isEgress != *awsRule.IsEgress {
 		return false
@@ -577,16 +862,73 @@ func (r *VpcEndpointReconciler) findOrCreateVpcEndpoint(ctx context.Context, res
 		}
 
 		r.log.V(1).Info("Searching for VPC Endpoint by tags")
-		resp, err = r.awsClient.FilterVPCEndpointByDefaultTags(ctx, r.clusterInfo.clusterTag, vpceName)
+		resp, err = r.awsClient.FilterVPCEndpointByDefaultTags(ctx, r.clusterInfo.clusterTag, vpceName, resource.Status.VPCId)
 		if err != nil {
 			return nil, err
 		}
 
+		// Ignore VPC Endpoints that are being deleted, e.g. to be recreated
+		if resp != nil {
+			resp.VpcEndpoints = slices.DeleteFunc(resp.VpcEndpoints, func(vpce ec2Types.VpcEndpoint) bool {
+				return vpce.State == "deleting" || vpce.State == "deleted"
+			})
+		}
+
 		// If there are still no VPC Endpoints found, it needs to be created
 		if resp == nil || len(resp.VpcEndpoints) == 0 {
 
-			creationResp, err := r.awsClient.CreateDefaultInterfaceVPCEndpoint(ctx, vpceName, resource.Status.VPCId, resource.Status.VPCEndpointServiceName, r.clusterInfo.clusterTag)
+			endpointType := vpcEndpointType(resource)
+
+			// Gateway Load Balancer VPC Endpoints must be created in their one and only subnet, and static IP
+			// addresses can only be assigned to subnets when they are added
+			var (
+				subnetIds            []string
+				subnetConfigurations []ec2Types.SubnetConfiguration
+			)
+			if endpointType == ec2Types.VpcEndpointTypeGatewayLoadBalancer || len(resource.Spec.Vpc.SubnetConfigurations) > 0 {
+				subnetIds, err = r.expectedSubnetIds(ctx, resource)
+				if err != nil {
+					return nil, err
+				}
+
+				if endpointType == ec2Types.VpcEndpointTypeGatewayLoadBalancer && len(subnetIds) != 1 {
+					return nil, fmt.Errorf("a Gateway Load Balancer VPC Endpoint requires exactly one subnet, found: %v", subnetIds)
+				}
+
+				subnetConfigurations, err = r.subnetConfigurations(ctx, resource, subnetIds, subnetIds)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			// Never create the VPC Endpoint with AWS' default full access policy if a policy was requested
+			policyDocument, err := r.vpcEndpointPolicyDocument(ctx, resource)
+			if err != nil {
+				return nil, err
+			}
+
+			creationResp, err := r.awsClient.CreateDefaultVPCEndpoint(ctx, vpceName, resource.Status.VPCId, resource.Status.VPCEndpointServiceName, r.clusterInfo.clusterTag, endpointType, func(input *ec2.CreateVpcEndpointInput) {
+				input.SubnetIds = subnetIds
+				input.SubnetConfigurations = subnetConfigurations
+				if endpointType != ec2Types.VpcEndpointTypeGateway && resource.Spec.IpAddressType != "" {
+					input.IpAddressType = ec2Types.IpAddressType(resource.Spec.IpAddressType)
+				}
+				if policyDocument != "" {
+					input.PolicyDocument = aws.String(policyDocument)
+				}
+				if endpointType != ec2Types.VpcEndpointTypeInterface {
+					return
+				}
+
+				// Always explicitly set PrivateDnsEnabled, otherwise AWS enables it by default for AWS services
+				input.PrivateDnsEnabled = aws.Bool(resource.Spec.EnablePrivateDns && privateDnsNameVerified(resource))
+				input.DnsOptions = dnsOptionsSpecification(resource.Spec.DnsOptions)
+			})
 			if err != nil {
+				if aws_client.IsVpcEndpointLimitExceeded(err) {
+					return nil, r.fallBackFromFullVpc(ctx, resource, err)
+				}
+
 				return nil, fmt.Errorf("failed to create vpc endpoint: %w", err)
 			}
 
@@ -607,6 +949,7 @@ func (r *VpcEndpointReconciler) findOrCreateVpcEndpoint(ctx context.Context, res
 
 	resource.Status.VPCEndpointId = *vpce.VpcEndpointId
 	resource.Status.Status = string(vpce.State)
+	resource.Status.NetworkInterfaceIds = vpce.NetworkInterfaceIds
 	if err := r.Status().Update(ctx, resource); err != nil {
 		return nil, fmt.Errorf("failed to update status: %w", err)
 	}
@@ -614,68 +957,330 @@ func (r *VpcEndpointReconciler) findOrCreateVpcEndpoint(ctx context.Context, res
 	return vpce, nil
 }
 
-// ensureVpcEndpointSubnets ensures that the subnets attached to the VPC Endpoint are the expected subnet ids
-func (r *VpcEndpointReconciler) ensureVpcEndpointSubnets(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
-	var (
-		subnetsToAdd, subnetsToRemove []string
-	)
+// vpcEndpointType returns the type of VPC Endpoint specified by .spec.type, defaulting to Interface
+func vpcEndpointType(resource *avov1alpha2.VpcEndpoint) ec2Types.VpcEndpointType {
+	if resource.Spec.Type == "" {
+		return ec2Types.VpcEndpointTypeInterface
+	}
+
+	return ec2Types.VpcEndpointType(resource.Spec.Type)
+}
+
+// vpcEndpointSupportsSecurityGroups returns true if the type of VPC Endpoint specified by .spec.type can have
+// security groups associated with it, which is only the case for Interface VPC Endpoints
+func vpcEndpointSupportsSecurityGroups(resource *avov1alpha2.VpcEndpoint) bool {
+	return vpcEndpointType(resource) == ec2Types.VpcEndpointTypeInterface
+}
+
+// expectedSubnetIds returns the subnet ids that should be associated with the VPC Endpoint, either specified
+// explicitly in .spec.vpc.subnetIds or auto-discovered from the private subnets in availability zones supported by the
+// VPC Endpoint Service. Only a single subnet is returned for Gateway Load Balancer VPC Endpoints.
+func (r *VpcEndpointReconciler) expectedSubnetIds(ctx context.Context, resource *avov1alpha2.VpcEndpoint) ([]string, error) {
+	var expectedSubnetIds []string
 
 	if resource.Spec.Vpc.AutoDiscoverSubnets {
 		var discoveredSubnets []ec2Types.Subnet
 		if len(resource.Spec.Vpc.Ids) > 0 || len(resource.Spec.Vpc.Tags) > 0 {
 			// Do not expect private subnets to have the cluster id when load balancing vpc ids
-			privateSubnets, err := r.awsClient.AutodiscoverPrivateSubnets(ctx, "", resource.Spec.Vpc.SubnetTags...)
+			var vpcIds []string
+			if resource.Status.VPCId != "" {
+				vpcIds = []string{resource.Status.VPCId}
+			}
+			privateSubnets, err := r.awsClient.DiscoverPrivateSubnets(ctx, resource.Spec.Vpc.SubnetDiscoveryMode, "", vpcIds, resource.Spec.Vpc.SubnetTags...)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			r.log.V(1).Info("Discovered private subnet(s):", "subnets", privateSubnets)
 			discoveredSubnets = privateSubnets
 		} else {
 			if r.clusterInfo == nil || r.clusterInfo.clusterTag == "" {
-				return fmt.Errorf("unable to parse cluster tag: %v", r.clusterInfo)
+				return nil, fmt.Errorf("unable to parse cluster tag: %v", r.clusterInfo)
 			}
 
-			privateSubnets, err := r.awsClient.AutodiscoverPrivateSubnets(ctx, r.clusterInfo.clusterTag, resource.Spec.Vpc.SubnetTags...)
+			privateSubnets, err := r.awsClient.DiscoverPrivateSubnets(ctx, resource.Spec.Vpc.SubnetDiscoveryMode, r.clusterInfo.clusterTag, nil, resource.Spec.Vpc.SubnetTags...)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			r.log.V(1).Info("Discovered private subnet(s):", "subnets", privateSubnets)
 			discoveredSubnets = privateSubnets
 		}
 
 		// When auto-discovering the cluster's private subnet ids, only subnets supported by the VPC Endpoint
-		// Service should be attached
-		allowedAZs, err := r.awsClient.GetVpcEndpointServiceAZs(ctx, resource.Status.VPCEndpointServiceName)
+		// Service should be attached. AZ names are mapped to different physical zones per AWS account, so they are
+		// matched by zone id instead.
+		allowedAZs, err := r.awsClient.GetVpcEndpointServiceAZIds(ctx, resource.Status.VPCEndpointServiceName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		var expectedSubnetIds []string
+		var candidates []ec2Types.Subnet
 		for _, subnet := range discoveredSubnets {
-			for _, az := range allowedAZs {
-				if *subnet.AvailabilityZone == az {
-					if resource.Status.VPCId != "" {
-						// If the VPCE's status contains a VPC id, only select subnets from that VPC
-						if *subnet.VpcId == resource.Status.VPCId {
-							expectedSubnetIds = append(expectedSubnetIds, *subnet.SubnetId)
-						}
-					} else {
-						// Otherwise, just filter if the subnet's AZ matches
-						expectedSubnetIds = append(expectedSubnetIds, *subnet.SubnetId)
-					}
-					break
-				}
+			if _, ok := allowedAZs[aws.ToString(subnet.AvailabilityZoneId)]; !ok {
+				continue
+			}
+
+			// If the VPCE's status contains a VPC id, only select subnets from that VPC
+			if resource.Status.VPCId != "" && aws.ToString(subnet.VpcId) != resource.Status.VPCId {
+				continue
 			}
+
+			candidates = append(candidates, subnet)
+		}
+		setServiceAZCoverageCondition(resource, allowedAZs, candidates)
+
+		// A VPC Endpoint supports only one subnet per Availability Zone, otherwise AWS rejects the modification with
+		// DuplicateSubnetsInSameZone, so deterministically select one
+		resource.Status.AvailabilityZoneSubnets = selectSubnetPerAZ(candidates, resource.Spec.Vpc, resource.Status.AvailabilityZoneSubnets)
+		azs := make([]string, 0, len(resource.Status.AvailabilityZoneSubnets))
+		for az := range resource.Status.AvailabilityZoneSubnets {
+			azs = append(azs, az)
+		}
+		slices.Sort(azs)
+		for _, az := range azs {
+			expectedSubnetIds = append(expectedSubnetIds, resource.Status.AvailabilityZoneSubnets[az])
 		}
 
 		r.log.V(1).Info("Private subnet(s) in availability zones supported by the VPC Endpoint Service:", "subnets", expectedSubnetIds, "serviceName", resource.Status.VPCEndpointServiceName)
-		subnetsToAdd, subnetsToRemove = util.StringSliceTwoWayDiff(vpce.SubnetIds, expectedSubnetIds)
 	} else {
 		// When subnet ids are specified, use exactly those subnets
-		subnetsToAdd, subnetsToRemove = util.StringSliceTwoWayDiff(vpce.SubnetIds, resource.Spec.Vpc.SubnetIds)
+		expectedSubnetIds = resource.Spec.Vpc.SubnetIds
 	}
 
-	// Removing subnets first before adding to avoid
+	// Gateway Load Balancer VPC Endpoints support exactly one subnet, so deterministically select one
+	if vpcEndpointType(resource) == ec2Types.VpcEndpointTypeGatewayLoadBalancer && len(expectedSubnetIds) > 1 {
+		selected := slices.Min(expectedSubnetIds)
+		r.log.V(1).Info("Selected a single subnet for the Gateway Load Balancer VPC Endpoint", "subnet", selected)
+		expectedSubnetIds = []string{selected}
+	}
+
+	// Dualstack and IPv6 VPC Endpoints can only be placed in subnets with an IPv6 CIDR block
+	if vpcEndpointRequiresIpv6Subnets(resource) {
+		missing, err := r.awsClient.SubnetsWithoutIpv6Cidr(ctx, expectedSubnetIds)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("subnet(s) %v have no IPv6 CIDR block, which is required for a VPC Endpoint with IP address type %s", missing, resource.Spec.IpAddressType)
+		}
+	}
+
+	return expectedSubnetIds, nil
+}
+
+// setServiceAZCoverageCondition sets the AWSServiceAZCoverageCondition, listing the AZs supported by the VPC Endpoint
+// Service, a map of zone id to AZ name, that none of the subnets are in
+func setServiceAZCoverageCondition(resource *avov1alpha2.VpcEndpoint, serviceAZs map[string]string, subnets []ec2Types.Subnet) {
+	var uncovered []string
+	for zoneId, name := range serviceAZs {
+		if !slices.ContainsFunc(subnets, func(subnet ec2Types.Subnet) bool {
+			return aws.ToString(subnet.AvailabilityZoneId) == zoneId
+		}) {
+			uncovered = append(uncovered, fmt.Sprintf("%s (%s)", zoneId, name))
+		}
+	}
+	slices.Sort(uncovered)
+
+	if len(uncovered) > 0 {
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSServiceAZCoverageCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "UncoveredAvailabilityZones",
+			Message: fmt.Sprintf("No subnets found in Availability Zone(s) supported by the VPC Endpoint Service: %s", strings.Join(uncovered, ", ")),
+		})
+		return
+	}
+
+	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+		Type:    avov1alpha2.AWSServiceAZCoverageCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Covered",
+		Message: fmt.Sprintf("Subnets found in all %d Availability Zone(s) supported by the VPC Endpoint Service", len(serviceAZs)),
+	})
+}
+
+// selectSubnetPerAZ returns the subnet selected in each Availability Zone out of subnets according to
+// .spec.vpc.subnetSelectionPolicy. previous is the selection from the last reconcile, which MostFreeIps keeps as long as
+// the subnet is still a candidate so that the VPC Endpoint doesn't move between subnets as their free IPs change.
+// Subnets with fewer free IP addresses than .spec.vpc.minFreeIpAddresses are only selected if there are no others in
+// their Availability Zone. Remaining ties are broken by the lowest subnet id.
+func selectSubnetPerAZ(subnets []ec2Types.Subnet, vpc avov1alpha2.Vpc, previous map[string]string) map[string]string {
+	perAZ := map[string][]ec2Types.Subnet{}
+	for _, subnet := range subnets {
+		if subnet.AvailabilityZone == nil || subnet.SubnetId == nil {
+			continue
+		}
+		perAZ[*subnet.AvailabilityZone] = append(perAZ[*subnet.AvailabilityZone], subnet)
+	}
+
+	if len(perAZ) == 0 {
+		return nil
+	}
+
+	selected := make(map[string]string, len(perAZ))
+	for az, azSubnets := range perAZ {
+		slices.SortFunc(azSubnets, func(a, b ec2Types.Subnet) int {
+			return strings.Compare(*a.SubnetId, *b.SubnetId)
+		})
+
+		// Deprioritise subnets without enough free IP addresses for the VPC Endpoint's network interface, unless the
+		// VPC Endpoint is already in them
+		if enough := slices.DeleteFunc(slices.Clone(azSubnets), func(subnet ec2Types.Subnet) bool {
+			return aws.ToInt32(subnet.AvailableIpAddressCount) < vpc.MinFreeIpAddresses && *subnet.SubnetId != previous[az]
+		}); len(enough) > 0 {
+			azSubnets = enough
+		}
+
+		best := azSubnets[0]
+		switch vpc.SubnetSelectionPolicy {
+		case subnetSelectionPolicyMostFreeIps:
+			if i := slices.IndexFunc(azSubnets, func(subnet ec2Types.Subnet) bool {
+				return *subnet.SubnetId == previous[az]
+			}); i >= 0 {
+				best = azSubnets[i]
+				break
+			}
+
+			for _, subnet := range azSubnets[1:] {
+				if aws.ToInt32(subnet.AvailableIpAddressCount) > aws.ToInt32(best.AvailableIpAddressCount) {
+					best = subnet
+				}
+			}
+		case subnetSelectionPolicyPreferredTag:
+			if vpc.PreferredSubnetTag == nil {
+				break
+			}
+
+			if i := slices.IndexFunc(azSubnets, func(subnet ec2Types.Subnet) bool {
+				return subnetHasTag(subnet, *vpc.PreferredSubnetTag)
+			}); i >= 0 {
+				best = azSubnets[i]
+			}
+		}
+
+		selected[az] = *best.SubnetId
+	}
+
+	return selected
+}
+
+// subnetHasTag returns true if the subnet has the tag's key and, if the tag's value is not empty, its value
+func subnetHasTag(subnet ec2Types.Subnet, tag avov1alpha2.Tag) bool {
+	return slices.ContainsFunc(subnet.Tags, func(t ec2Types.Tag) bool {
+		return aws.ToString(t.Key) == tag.Key && (tag.Value == "" || aws.ToString(t.Value) == tag.Value)
+	})
+}
+
+// vpcEndpointRequiresIpv6Subnets returns true if the IP address type specified by .spec.ipAddressType requires the
+// VPC Endpoint's subnets to have IPv6 CIDR blocks
+func vpcEndpointRequiresIpv6Subnets(resource *avov1alpha2.VpcEndpoint) bool {
+	return resource.Spec.IpAddressType == string(ec2Types.IpAddressTypeDualstack) ||
+		resource.Spec.IpAddressType == string(ec2Types.IpAddressTypeIpv6)
+}
+
+// ensureVpcEndpointIpAddressType ensures that the IP address type of the VPC Endpoint matches .spec.ipAddressType.
+// The DNS options are modified at the same time since AWS requires their DNS record IP type to be compatible.
+// Gateway VPC Endpoints only support IPv4, so this is a no-op for them.
+func (r *VpcEndpointReconciler) ensureVpcEndpointIpAddressType(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	if vpcEndpointType(resource) == ec2Types.VpcEndpointTypeGateway || resource.Spec.IpAddressType == "" {
+		return nil
+	}
+
+	if string(vpce.IpAddressType) == resource.Spec.IpAddressType {
+		return nil
+	}
+
+	input := &ec2.ModifyVpcEndpointInput{
+		VpcEndpointId: vpce.VpcEndpointId,
+		IpAddressType: ec2Types.IpAddressType(resource.Spec.IpAddressType),
+	}
+	if vpcEndpointType(resource) == ec2Types.VpcEndpointTypeInterface {
+		input.DnsOptions = dnsOptionsSpecification(resource.Spec.DnsOptions)
+	}
+
+	r.log.V(1).Info("Modifying VPC Endpoint IP address type", "from", vpce.IpAddressType, "to", resource.Spec.IpAddressType)
+	if _, err := r.awsClient.ModifyVpcEndpoint(ctx, input); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Modified", "Modified IP address type of VPC endpoint: %s", *vpce.VpcEndpointId)
+
+	// Reflect the changes so that ensureVpcEndpointPrivateDns does not modify the DNS options again
+	vpce.IpAddressType = input.IpAddressType
+	if input.DnsOptions != nil {
+		vpce.DnsOptions = &ec2Types.DnsOptions{
+			DnsRecordIpType:                          input.DnsOptions.DnsRecordIpType,
+			PrivateDnsOnlyForInboundResolverEndpoint: input.DnsOptions.PrivateDnsOnlyForInboundResolverEndpoint,
+		}
+	}
+
+	return nil
+}
+
+// ensureVpcEndpointSubnets ensures that the subnets attached to the VPC Endpoint are the expected subnet ids.
+// Gateway VPC Endpoints are not associated with subnets, so this is a no-op for them.
+func (r *VpcEndpointReconciler) ensureVpcEndpointSubnets(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	if vpcEndpointType(resource) == ec2Types.VpcEndpointTypeGateway {
+		return nil
+	}
+
+	// AWS rejects adding a subnet back while the VPC Endpoint's previous network interface in it is still deleting
+	if len(resource.Status.ReplacedNetworkInterfaceIds) > 0 {
+		enis, err := r.awsClient.FilterNetworkInterfacesByIds(ctx, resource.Status.ReplacedNetworkInterfaceIds)
+		if err != nil {
+			return err
+		}
+		if len(enis) > 0 {
+			return &requeueAfterError{after: subnetReplacementRequeueDelay, reason: fmt.Sprintf("waiting for network interfaces %v to be deleted before replacing them", resource.Status.ReplacedNetworkInterfaceIds)}
+		}
+
+		resource.Status.ReplacedNetworkInterfaceIds = nil
+	}
+
+	expectedSubnetIds, err := r.expectedSubnetIds(ctx, resource)
+	if err != nil {
+		return err
+	}
+	subnetsToAdd, subnetsToRemove := util.StringSliceTwoWayDiff(vpce.SubnetIds, expectedSubnetIds)
+
+	// The static IP address of a subnet can't be modified, so the subnet is removed and added back once its network
+	// interface is deleted
+	replaced, err := r.subnetsWithChangedAddresses(ctx, vpce, resource)
+	if err != nil {
+		return err
+	}
+	var replacing []string
+	for _, subnetId := range replaced {
+		if slices.Contains(expectedSubnetIds, subnetId) && !slices.Contains(subnetsToRemove, subnetId) {
+			r.log.V(0).Info("Replacing subnet to change its static IP address", "subnet", subnetId)
+			subnetsToRemove = append(subnetsToRemove, subnetId)
+			replacing = append(replacing, subnetId)
+		}
+	}
+
+	var replacedNetworkInterfaceIds []string
+	if len(replacing) > 0 {
+		enis, err := r.awsClient.FilterNetworkInterfacesByIds(ctx, vpce.NetworkInterfaceIds)
+		if err != nil {
+			return err
+		}
+		for _, eni := range enis {
+			if slices.Contains(replacing, aws.ToString(eni.SubnetId)) {
+				replacedNetworkInterfaceIds = append(replacedNetworkInterfaceIds, aws.ToString(eni.NetworkInterfaceId))
+			}
+		}
+	}
+
+	subnetsToAdd, err = r.checkSubnetCapacity(ctx, resource, expectedSubnetIds, subnetsToAdd, replaced)
+	if err != nil {
+		return err
+	}
+
+	subnetConfigurations, err := r.subnetConfigurations(ctx, resource, expectedSubnetIds, subnetsToAdd)
+	if err != nil {
+		return err
+	}
+
+	// Removing subnets first before adding to avoid
 	// DuplicateSubnetsInSameZone: Found another VPC endpoint subnet in the availability zone of <existing subnet>
 	if len(subnetsToRemove) > 0 {
 		r.log.V(1).Info("Removing subnet(s) from VPC Endpoint", "subnetsToRemove", subnetsToRemove)
@@ -690,20 +1295,120 @@ func (r *VpcEndpointReconciler) ensureVpcEndpointSubnets(ctx context.Context, vp
 	if len(subnetsToAdd) > 0 {
 		r.log.V(1).Info("Adding subnet(s) to VPC Endpoint", "subnetsToAdd", subnetsToAdd)
 		if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
-			AddSubnetIds:  subnetsToAdd,
-			VpcEndpointId: vpce.VpcEndpointId,
+			AddSubnetIds:         subnetsToAdd,
+			SubnetConfigurations: subnetConfigurations,
+			VpcEndpointId:        vpce.VpcEndpointId,
 		}); err != nil {
 			return fmt.Errorf("failed to add subnets: %v with error: %w", subnetsToAdd, err)
 		}
 	}
 
+	if len(replacedNetworkInterfaceIds) > 0 {
+		resource.Status.ReplacedNetworkInterfaceIds = replacedNetworkInterfaceIds
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+
+		return &requeueAfterError{after: subnetReplacementRequeueDelay, reason: fmt.Sprintf("waiting for network interfaces %v to be deleted before replacing them", replacedNetworkInterfaceIds)}
+	}
+
+	if len(subnetsToAdd) > 0 || len(subnetsToRemove) > 0 {
+		vpce.SubnetIds = append(slices.DeleteFunc(slices.Clone(vpce.SubnetIds), func(subnetId string) bool {
+			return slices.Contains(subnetsToRemove, subnetId)
+		}), subnetsToAdd...)
+	}
+
 	return nil
 }
 
-// ensureVpcEndpointSecurityGroups ensures that the security group associated with the VPC Endpoint
-// is only the expected one.
+// subnetConfigurations validates .spec.vpc.subnetConfigurations against the VPC Endpoint's expectedSubnetIds and
+// returns the configurations of the subnets in subnetIds, e.g. the subnets being added to the VPC Endpoint
+func (r *VpcEndpointReconciler) subnetConfigurations(ctx context.Context, resource *avov1alpha2.VpcEndpoint, expectedSubnetIds, subnetIds []string) ([]ec2Types.SubnetConfiguration, error) {
+	if len(resource.Spec.Vpc.SubnetConfigurations) == 0 {
+		return nil, nil
+	}
+
+	for _, config := range resource.Spec.Vpc.SubnetConfigurations {
+		if !slices.Contains(expectedSubnetIds, config.SubnetId) {
+			return nil, fmt.Errorf("subnet %s in .spec.vpc.subnetConfigurations is not one of the VPC Endpoint's subnets: %v", config.SubnetId, expectedSubnetIds)
+		}
+	}
+
+	if err := r.awsClient.ValidateSubnetConfigurations(ctx, resource.Spec.Vpc.SubnetConfigurations); err != nil {
+		return nil, err
+	}
+
+	var subnetConfigurations []ec2Types.SubnetConfiguration
+	for _, config := range resource.Spec.Vpc.SubnetConfigurations {
+		if !slices.Contains(subnetIds, config.SubnetId) {
+			continue
+		}
+
+		subnetConfiguration := ec2Types.SubnetConfiguration{SubnetId: aws.String(config.SubnetId)}
+		if config.Ipv4 != "" {
+			subnetConfiguration.Ipv4 = aws.String(config.Ipv4)
+		}
+		if config.Ipv6 != "" {
+			subnetConfiguration.Ipv6 = aws.String(config.Ipv6)
+		}
+		subnetConfigurations = append(subnetConfigurations, subnetConfiguration)
+	}
+
+	return subnetConfigurations, nil
+}
+
+// subnetsWithChangedAddresses returns the VPC Endpoint's subnets whose network interface doesn't have the static IP
+// addresses specified by .spec.vpc.subnetConfigurations
+func (r *VpcEndpointReconciler) subnetsWithChangedAddresses(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) ([]string, error) {
+	if len(resource.Spec.Vpc.SubnetConfigurations) == 0 || len(vpce.NetworkInterfaceIds) == 0 {
+		return nil, nil
+	}
+
+	addresses, err := r.awsClient.NetworkInterfaceAddressesBySubnet(ctx, vpce.NetworkInterfaceIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, config := range resource.Spec.Vpc.SubnetConfigurations {
+		current, ok := addresses[config.SubnetId]
+		if !ok || !slices.Contains(vpce.SubnetIds, config.SubnetId) {
+			// The subnet isn't attached yet, so it will be added with its configuration
+			continue
+		}
+
+		if (config.Ipv4 != "" && !containsAddress(current, config.Ipv4)) || (config.Ipv6 != "" && !containsAddress(current, config.Ipv6)) {
+			changed = append(changed, config.SubnetId)
+		}
+	}
+
+	return changed, nil
+}
+
+// containsAddress returns true if addresses contains address, comparing parsed IP addresses so that differently
+// formatted IPv6 addresses are equal
+func containsAddress(addresses []string, address string) bool {
+	want, err := netip.ParseAddr(address)
+	if err != nil {
+		return slices.Contains(addresses, address)
+	}
+
+	return slices.ContainsFunc(addresses, func(a string) bool {
+		addr, err := netip.ParseAddr(a)
+		return err == nil && addr == want
+	})
+}
+
+// ensureVpcEndpointSecurityGroups ensures that the security groups associated with the VPC Endpoint
+// are only the expected ones. Gateway and Gateway Load Balancer VPC Endpoints do not support security groups, so this
+// is a no-op for them.
 func (r *VpcEndpointReconciler) ensureVpcEndpointSecurityGroups(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
-	sgToAdd, sgToRemove, err := r.diffVpcEndpointSecurityGroups(vpce, resource)
+	if !vpcEndpointSupportsSecurityGroups(resource) {
+		return nil
+	}
+
+	sgToAdd, sgToRemove, err := r.diffVpcEndpointSecurityGroups(ctx, vpce, resource)
 	if err != nil {
 		return err
 	}
@@ -731,23 +1436,215 @@ func (r *VpcEndpointReconciler) ensureVpcEndpointSecurityGroups(ctx context.Cont
 	return nil
 }
 
+// ensureVpcEndpointRouteTables ensures that the route tables associated with a Gateway VPC Endpoint are the expected
+// route table ids. Only Gateway VPC Endpoints are associated with route tables, so this is a no-op for other types.
+func (r *VpcEndpointReconciler) ensureVpcEndpointRouteTables(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	if vpcEndpointType(resource) != ec2Types.VpcEndpointTypeGateway {
+		return nil
+	}
+
+	expectedRouteTableIds, err := r.expectedRouteTableIds(ctx, resource)
+	if err != nil {
+		return err
+	}
+
+	routeTablesToAdd, routeTablesToRemove := util.StringSliceTwoWayDiff(vpce.RouteTableIds, expectedRouteTableIds)
+	if len(routeTablesToAdd) == 0 && len(routeTablesToRemove) == 0 {
+		return nil
+	}
+
+	r.log.V(1).Info("Modifying VPC Endpoint route tables", "routeTablesToAdd", routeTablesToAdd, "routeTablesToRemove", routeTablesToRemove)
+	if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
+		AddRouteTableIds:    routeTablesToAdd,
+		RemoveRouteTableIds: routeTablesToRemove,
+		VpcEndpointId:       vpce.VpcEndpointId,
+	}); err != nil {
+		return fmt.Errorf("failed to add route tables: %v and remove route tables: %v with error: %w", routeTablesToAdd, routeTablesToRemove, err)
+	}
+
+	return nil
+}
+
+// expectedRouteTableIds returns the route table ids that should be associated with a Gateway VPC Endpoint, either
+// specified explicitly in .spec.vpc.routeTableIds or discovered from the VPC Endpoint's VPC, optionally filtered by
+// .spec.vpc.routeTableTags
+func (r *VpcEndpointReconciler) expectedRouteTableIds(ctx context.Context, resource *avov1alpha2.VpcEndpoint) ([]string, error) {
+	if len(resource.Spec.Vpc.RouteTableIds) > 0 {
+		return resource.Spec.Vpc.RouteTableIds, nil
+	}
+
+	routeTables, err := r.awsClient.FilterRouteTablesByTags(ctx, resource.Status.VPCId, resource.Spec.Vpc.RouteTableTags...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(routeTables) == 0 {
+		return nil, fmt.Errorf("no route tables found in %s with tags: %v", resource.Status.VPCId, resource.Spec.Vpc.RouteTableTags)
+	}
+
+	routeTableIds := make([]string, len(routeTables))
+	for i := range routeTables {
+		routeTableIds[i] = *routeTables[i].RouteTableId
+	}
+	r.log.V(1).Info("Discovered route table(s):", "routeTables", routeTableIds)
+
+	return routeTableIds, nil
+}
+
 // diffVpcEndpointSecurityGroups compares the security groups associated with the VPC Endpoint with
-// the security group ID recorded in the resource's status, returning security groups that need to be added
+// the expected security groups, see expectedSecurityGroupIds, returning security groups that need to be added
 // and security groups that need to be removed from the VPC Endpoint.
-func (r *VpcEndpointReconciler) diffVpcEndpointSecurityGroups(vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) ([]string, []string, error) {
+func (r *VpcEndpointReconciler) diffVpcEndpointSecurityGroups(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) ([]string, []string, error) {
 	vpceSgIds := make([]string, len(vpce.Groups))
 	for i := range vpce.Groups {
 		vpceSgIds[i] = *vpce.Groups[i].GroupId
 	}
 
+	expectedSgIds, err := r.expectedSecurityGroupIds(ctx, resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	sgToAdd, sgToRemove := util.StringSliceTwoWayDiff(
 		vpceSgIds,
-		[]string{resource.Status.SecurityGroupId},
+		expectedSgIds,
 	)
 
 	return sgToAdd, sgToRemove, nil
 }
 
+// expectedSecurityGroupIds returns the security groups that should be associated with the VPC Endpoint: the managed
+// security group recorded in the resource's status, unless .spec.securityGroup.mode is UserProvided, and the
+// additional security groups specified by id or discovered by tags in the VPC Endpoint's VPC. The additional security
+// groups are recorded in the resource's status, but the status is not persisted, that is left to the caller.
+func (r *VpcEndpointReconciler) expectedSecurityGroupIds(ctx context.Context, resource *avov1alpha2.VpcEndpoint) ([]string, error) {
+	var sgIds []string
+	if resource.Spec.SecurityGroup.Mode != securityGroupModeUserProvided {
+		sgIds = append(sgIds, resource.Status.SecurityGroupId)
+	}
+
+	additionalSgIds := slices.Clone(resource.Spec.SecurityGroup.AdditionalSecurityGroupIds)
+	if len(resource.Spec.SecurityGroup.AdditionalSecurityGroupTags) > 0 {
+		securityGroups, err := r.awsClient.FilterSecurityGroupsByTags(ctx, resource.Status.VPCId, resource.Spec.SecurityGroup.AdditionalSecurityGroupTags...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sg := range securityGroups {
+			if !slices.Contains(additionalSgIds, *sg.GroupId) {
+				additionalSgIds = append(additionalSgIds, *sg.GroupId)
+			}
+		}
+	}
+	resource.Status.AdditionalSecurityGroupIds = additionalSgIds
+
+	sgIds = append(sgIds, additionalSgIds...)
+	if len(sgIds) == 0 {
+		return nil, fmt.Errorf("no security groups found in %s with ids: %v or tags: %v", resource.Status.VPCId,
+			resource.Spec.SecurityGroup.AdditionalSecurityGroupIds, resource.Spec.SecurityGroup.AdditionalSecurityGroupTags)
+	}
+
+	return sgIds, nil
+}
+
+// updatePrivateDnsNameStatus records the private DNS name of the VPC Endpoint Service and its verification state
+// in the resource's status. The status is not persisted, that is left to the caller.
+func (r *VpcEndpointReconciler) updatePrivateDnsNameStatus(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	if resource.Status.VPCEndpointServiceName == "" {
+		return nil
+	}
+
+	service, err := r.awsClient.DescribeVpcEndpointService(ctx, resource.Status.VPCEndpointServiceName)
+	if err != nil {
+		return err
+	}
+
+	resource.Status.PrivateDnsName = aws.ToString(service.PrivateDnsName)
+	resource.Status.PrivateDnsNameVerificationState = string(service.PrivateDnsNameVerificationState)
+
+	return nil
+}
+
+// privateDnsNameVerified returns true if the VPC Endpoint Service recorded in the resource's status has a verified
+// private DNS name, which is required before private DNS can be enabled on a VPC Endpoint
+func privateDnsNameVerified(resource *avov1alpha2.VpcEndpoint) bool {
+	return resource.Status.PrivateDnsName != "" &&
+		resource.Status.PrivateDnsNameVerificationState == string(ec2Types.DnsNameStateVerified)
+}
+
+// ensureVpcEndpointPrivateDns ensures that private DNS and the DNS options of the VPC Endpoint match .spec.
+// Private DNS is only enabled once the VPC Endpoint Service's private DNS name has been verified, but is left
+// alone if the VPC Endpoint already has it enabled. Only Interface VPC Endpoints support private DNS.
+func (r *VpcEndpointReconciler) ensureVpcEndpointPrivateDns(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	if vpcEndpointType(resource) != ec2Types.VpcEndpointTypeInterface {
+		return nil
+	}
+
+	input := &ec2.ModifyVpcEndpointInput{
+		VpcEndpointId: vpce.VpcEndpointId,
+	}
+	needsUpdate := false
+
+	switch {
+	case !resource.Spec.EnablePrivateDns && aws.ToBool(vpce.PrivateDnsEnabled):
+		input.PrivateDnsEnabled = aws.Bool(false)
+		needsUpdate = true
+	case resource.Spec.EnablePrivateDns && !aws.ToBool(vpce.PrivateDnsEnabled) && privateDnsNameVerified(resource):
+		input.PrivateDnsEnabled = aws.Bool(true)
+		needsUpdate = true
+	}
+
+	if dnsOptionsNeedUpdate(vpce.DnsOptions, resource.Spec.DnsOptions) {
+		input.DnsOptions = dnsOptionsSpecification(resource.Spec.DnsOptions)
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		return nil
+	}
+
+	r.log.V(1).Info("Modifying VPC Endpoint DNS settings", "privateDnsEnabled", input.PrivateDnsEnabled, "dnsOptions", resource.Spec.DnsOptions)
+	if _, err := r.awsClient.ModifyVpcEndpoint(ctx, input); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Modified", "Modified DNS settings of VPC endpoint: %s", *vpce.VpcEndpointId)
+
+	return nil
+}
+
+// dnsOptionsNeedUpdate returns true if any DNS option specified in .spec.dnsOptions differs from the VPC Endpoint's
+func dnsOptionsNeedUpdate(actual *ec2Types.DnsOptions, desired *avov1alpha2.DnsOptions) bool {
+	if desired == nil {
+		return false
+	}
+
+	if desired.DnsRecordIpType != "" {
+		if actual == nil || string(actual.DnsRecordIpType) != desired.DnsRecordIpType {
+			return true
+		}
+	}
+
+	if desired.PrivateDnsOnlyForInboundResolverEndpoint != nil {
+		if actual == nil || aws.ToBool(actual.PrivateDnsOnlyForInboundResolverEndpoint) != *desired.PrivateDnsOnlyForInboundResolverEndpoint {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dnsOptionsSpecification converts .spec.dnsOptions into its EC2 API representation
+func dnsOptionsSpecification(dnsOptions *avov1alpha2.DnsOptions) *ec2Types.DnsOptionsSpecification {
+	if dnsOptions == nil {
+		return nil
+	}
+
+	return &ec2Types.DnsOptionsSpecification{
+		DnsRecordIpType:                          ec2Types.DnsRecordIpType(dnsOptions.DnsRecordIpType),
+		PrivateDnsOnlyForInboundResolverEndpoint: dnsOptions.PrivateDnsOnlyForInboundResolverEndpoint,
+	}
+}
+
 // findOrCreatePrivateHostedZone ensures the existence of a Route53 Private Hosted Zone given a custom domain name
 func (r *VpcEndpointReconciler) findOrCreatePrivateHostedZone(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	if resource == nil {
@@ -845,39 +1742,239 @@ func (r *VpcEndpointReconciler) findOrCreatePrivateHostedZone(ctx context.Contex
 	return nil
 }
 
-// generateRoute53Record generates the expected Route53 Record for a provided VpcEndpoint CR
-func (r *VpcEndpointReconciler) generateRoute53Record(ctx context.Context, resource *avov1alpha2.VpcEndpoint) (*route53Types.ResourceRecord, error) {
+// route53RecordName returns the FQDN of the record with the given hostname in the hosted zone named zoneName
+func route53RecordName(hostname, zoneName string) string {
+	zoneName = strings.TrimRight(zoneName, ".")
+	if hostname == zoneApexHostname {
+		return zoneName
+	}
+
+	return fmt.Sprintf("%s.%s", hostname, zoneName)
+}
+
+// route53Records returns the configured records of a VpcEndpoint CR, .spec.customDns.route53PrivateHostedZone.record
+// first if it's specified
+func route53Records(resource *avov1alpha2.VpcEndpoint) []avov1alpha2.Route53HostedZoneRecord {
+	var records []avov1alpha2.Route53HostedZoneRecord
+	if resource.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname != "" {
+		records = append(records, resource.Spec.CustomDns.Route53PrivateHostedZone.Record)
+	}
+
+	return append(records, resource.Spec.CustomDns.Route53PrivateHostedZone.Records...)
+}
+
+// ownedRoute53RecordNames returns the FQDNs of the Route53 Records created for a VpcEndpoint CR
+func ownedRoute53RecordNames(resource *avov1alpha2.VpcEndpoint) []string {
+	names := slices.Clone(resource.Status.ResourceRecordSets)
+	// VpcEndpoints reconciled before .status.resourceRecordSets was introduced only track a single record
+	if resource.Status.ResourceRecordSet != "" && !slices.Contains(names, resource.Status.ResourceRecordSet) {
+		names = append(names, resource.Status.ResourceRecordSet)
+	}
+
+	return names
+}
+
+// generateRoute53Records generates the expected Route53 Records in the hosted zone named zoneName for a provided
+// VpcEndpoint CR, and the names of the records with Availability Zone affinity by Availability Zone. It returns nil
+// without an error if the VPC Endpoint doesn't exist anymore or has no DNS entries while being deleted, and otherwise a
+// non-nil, possibly empty, slice.
+func (r *VpcEndpointReconciler) generateRoute53Records(ctx context.Context, resource *avov1alpha2.VpcEndpoint, zoneName string) ([]*route53Types.ResourceRecordSet, map[string][]string, error) {
 	if resource.Status.VPCEndpointId == "" {
-		return nil, fmt.Errorf("VPCEndpointID status is missing")
+		return nil, nil, fmt.Errorf("VPCEndpointID status is missing")
 	}
 
 	vpceResp, err := r.awsClient.DescribeSingleVPCEndpointById(ctx, resource.Status.VPCEndpointId)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// VPCEndpoint doesn't exist anymore for some reason
 	if vpceResp == nil || len(vpceResp.VpcEndpoints) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	// DNSEntries won't be populated until the state is available
 	if string(vpceResp.VpcEndpoints[0].State) != "available" {
-		return nil, fmt.Errorf("VPCEndpoint is not in the available state")
+		return nil, nil, fmt.Errorf("VPCEndpoint is not in the available state")
 	}
 
 	if len(vpceResp.VpcEndpoints[0].DnsEntries) == 0 {
 		if !resource.ObjectMeta.DeletionTimestamp.IsZero() {
 			// When we're deleting the VPC Endpoint, handle the edge case where it doesn't have any subnets attached anymore
-			return nil, nil
+			return nil, nil, nil
+		}
+
+		return nil, nil, fmt.Errorf("VPCEndpoint has no DNS entries")
+	}
+
+	// The first DNS entry is the regional DNS name of the VPC Endpoint, the rest are zonal
+	dnsEntry := vpceResp.VpcEndpoints[0].DnsEntries[0]
+	zonalEntries := zonalDnsEntries(vpceResp.VpcEndpoints[0].DnsEntries)
+	azs := make([]string, 0, len(zonalEntries))
+	for az := range zonalEntries {
+		azs = append(azs, az)
+	}
+	slices.Sort(azs)
+
+	records := make([]*route53Types.ResourceRecordSet, 0, len(route53Records(resource)))
+	var azRecords map[string][]string
+	for _, record := range route53Records(resource) {
+		name := route53RecordName(record.Hostname, zoneName)
+		records = append(records, generateRoute53Record(dnsEntry, record, name))
+
+		if record.AvailabilityZoneAffinity {
+			if azRecords == nil {
+				azRecords = map[string][]string{}
+			}
+
+			for _, az := range azs {
+				azName := fmt.Sprintf("%s.%s", az, name)
+				records = append(records, generateRoute53Record(zonalEntries[az], record, azName))
+				azRecords[az] = append(azRecords[az], azName)
+			}
+		}
+	}
+
+	return records, azRecords, nil
+}
+
+// zonalDnsEntries returns the zonal DNS entries of a VPC Endpoint by Availability Zone. The first label of a zonal DNS
+// name is the first label of the regional DNS name, the first DNS entry, followed by the Availability Zone, e.g.
+// vpce-0123-abcd-us-east-1a.vpce-svc-0123.us-east-1.vpce.amazonaws.com is in us-east-1a
+func zonalDnsEntries(dnsEntries []ec2Types.DnsEntry) map[string]ec2Types.DnsEntry {
+	zonal := map[string]ec2Types.DnsEntry{}
+	if len(dnsEntries) == 0 {
+		return zonal
+	}
+
+	regional, _, _ := strings.Cut(aws.ToString(dnsEntries[0].DnsName), ".")
+	for _, entry := range dnsEntries[1:] {
+		label, _, _ := strings.Cut(aws.ToString(entry.DnsName), ".")
+		// Private DNS names of the VPC Endpoint Service don't start with the regional DNS name's first label
+		if az, ok := strings.CutPrefix(label, regional+"-"); ok {
+			zonal[az] = entry
+		}
+	}
+
+	return zonal
+}
+
+// generateRoute53Record generates the Route53 Record named name for record, either a CNAME or an alias A/AAAA record
+// pointing to dnsEntry
+func generateRoute53Record(dnsEntry ec2Types.DnsEntry, record avov1alpha2.Route53HostedZoneRecord, name string) *route53Types.ResourceRecordSet {
+	switch recordType := route53Types.RRType(record.Type); recordType {
+	case route53Types.RRTypeA, route53Types.RRTypeAaaa:
+		return &route53Types.ResourceRecordSet{
+			Name: aws.String(name),
+			Type: recordType,
+			AliasTarget: &route53Types.AliasTarget{
+				DNSName:              dnsEntry.DnsName,
+				HostedZoneId:         dnsEntry.HostedZoneId,
+				EvaluateTargetHealth: false,
+			},
+		}
+	default:
+		ttl := record.TTL
+		if ttl == 0 {
+			ttl = defaultRecordTTL
+		}
+
+		return &route53Types.ResourceRecordSet{
+			Name:            aws.String(name),
+			Type:            route53Types.RRTypeCname,
+			TTL:             aws.Int64(ttl),
+			ResourceRecords: []route53Types.ResourceRecord{{Value: dnsEntry.DnsName}},
+		}
+	}
+}
+
+// staleRoute53Records returns the records in existing of another type than desired that AVO may have created for the
+// same name, which must be deleted to change the type of the record in place
+func staleRoute53Records(existing []route53Types.ResourceRecordSet, desired *route53Types.ResourceRecordSet) []route53Types.ResourceRecordSet {
+	var stale []route53Types.ResourceRecordSet
+	for _, rrs := range existing {
+		if isManagedRecordType(rrs.Type) && rrs.Type != desired.Type {
+			stale = append(stale, rrs)
+		}
+	}
+
+	return stale
+}
+
+// route53RecordUpToDate returns true if existing contains desired and no records AVO may have created for the same name
+// with another type
+func route53RecordUpToDate(existing []route53Types.ResourceRecordSet, desired *route53Types.ResourceRecordSet) bool {
+	if len(staleRoute53Records(existing, desired)) > 0 {
+		return false
+	}
+
+	// Route 53 may return DNS names in lowercase with a trailing "."
+	dnsNameEqual := func(a, b *string) bool {
+		return strings.EqualFold(strings.TrimSuffix(aws.ToString(a), "."), strings.TrimSuffix(aws.ToString(b), "."))
+	}
+
+	for _, rrs := range existing {
+		if rrs.Type != desired.Type {
+			continue
+		}
+
+		if desired.AliasTarget != nil {
+			return rrs.AliasTarget != nil &&
+				dnsNameEqual(rrs.AliasTarget.DNSName, desired.AliasTarget.DNSName) &&
+				aws.ToString(rrs.AliasTarget.HostedZoneId) == aws.ToString(desired.AliasTarget.HostedZoneId)
+		}
+
+		return rrs.AliasTarget == nil && aws.ToInt64(rrs.TTL) == aws.ToInt64(desired.TTL) &&
+			slices.EqualFunc(rrs.ResourceRecords, desired.ResourceRecords, func(a, b route53Types.ResourceRecord) bool {
+				return dnsNameEqual(a.Value, b.Value)
+			})
+	}
+
+	return false
+}
+
+// route53ChangeInSync returns true once a change to Route53 records has propagated to all Route 53 DNS servers
+func (r *VpcEndpointReconciler) route53ChangeInSync(ctx context.Context, changeId string) (bool, error) {
+	status, err := r.awsClient.GetChangeStatus(ctx, changeId)
+	if err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == new(route53Types.NoSuchChange).ErrorCode() {
+			// Changes are only available for a limited time, long after they have propagated
+			return true, nil
 		}
 
-		return nil, fmt.Errorf("VPCEndpoint has no DNS entries")
+		return false, err
 	}
 
-	return &route53Types.ResourceRecord{
-		Value: vpceResp.VpcEndpoints[0].DnsEntries[0].DnsName,
-	}, nil
+	return status == route53Types.ChangeStatusInsync, nil
+}
+
+// deleteRoute53Records deletes the CNAME and alias A/AAAA records named name that AVO created for a VpcEndpoint CR in a
+// hosted zone and their ownership TXT record, unless another VpcEndpoint CR owns them. index must contain the records
+// in the hosted zone.
+func (r *VpcEndpointReconciler) deleteRoute53Records(ctx context.Context, resource *avov1alpha2.VpcEndpoint, hostedZoneId string, index route53RecordIndex, name string) error {
+	owner, ownershipRecord := index.owner(name)
+	if owner != "" && owner != string(resource.UID) {
+		r.log.V(0).Info("Not deleting Route53 Hosted Zone Record owned by another VpcEndpoint", "name", name, "owner", owner)
+		return nil
+	}
+
+	for _, rrs := range index.named(name) {
+		if isManagedRecordType(rrs.Type) {
+			r.log.V(0).Info("Deleting Route53 Hosted Zone Record", "name", *rrs.Name, "type", rrs.Type)
+			if _, err := r.awsClient.DeleteResourceRecordSet(ctx, &rrs, hostedZoneId); err != nil {
+				return err
+			}
+		}
+	}
+
+	if ownershipRecord != nil {
+		if _, err := r.awsClient.DeleteResourceRecordSet(ctx, ownershipRecord, hostedZoneId); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // generateExternalNameService generates the expected ExternalName service for a VpcEndpoint CustomResource
@@ -19,10 +19,12 @@ package vpcendpoint
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/go-logr/logr/testr"
 	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
@@ -31,6 +33,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 )
@@ -236,6 +239,34 @@ func TestVpcEndpointReconciler_generateMissingSecurityGroupRules(t *testing.T) {
 			expectedNumIngress: 2,
 			expectErr:          false,
 		},
+		{
+			name: "egress rules are compared to egress rules",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock1",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						EgressRules: []avov1alpha2.SecurityGroupRule{
+							{
+								FromPort: 443,
+								ToPort:   443,
+								Protocol: "tcp",
+							},
+						},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					InfraId: testutil.MockInfrastructureName,
+				},
+			},
+			sg: &ec2Types.SecurityGroup{
+				GroupId: aws.String(aws_client.MockSecurityGroupId),
+			},
+			expectedNumEgress:  1,
+			expectedNumIngress: 0,
+			expectErr:          false,
+		},
 	}
 
 	for _, test := range tests {
@@ -314,6 +345,325 @@ func TestAvoAndAwsSecurityGroupRuleCandidate(t *testing.T) {
 	}
 }
 
+func TestUndesiredSecurityGroupRules(t *testing.T) {
+	awsRules := []ec2Types.SecurityGroupRule{
+		{
+			SecurityGroupRuleId: aws.String("sgr-cidr"),
+			CidrIpv4:            aws.String("10.0.0.0/16"),
+			FromPort:            aws.Int32(443),
+			IpProtocol:          aws.String("tcp"),
+			IsEgress:            aws.Bool(false),
+			ToPort:              aws.Int32(443),
+		},
+		{
+			SecurityGroupRuleId: aws.String("sgr-source"),
+			FromPort:            aws.Int32(443),
+			IpProtocol:          aws.String("tcp"),
+			IsEgress:            aws.Bool(false),
+			ReferencedGroupInfo: &ec2Types.ReferencedSecurityGroup{GroupId: aws.String(aws_client.MockSecurityGroupId)},
+			ToPort:              aws.Int32(443),
+		},
+		{
+			SecurityGroupRuleId: aws.String("sgr-egress"),
+			CidrIpv4:            aws.String("0.0.0.0/0"),
+			FromPort:            aws.Int32(-1),
+			IpProtocol:          aws.String("-1"),
+			IsEgress:            aws.Bool(true),
+			ToPort:              aws.Int32(-1),
+		},
+	}
+
+	tests := []struct {
+		name               string
+		annotations        map[string]string
+		spec               avov1alpha2.SecurityGroup
+		clusterSgsNotFound bool
+		expected           []string
+	}{
+		{
+			name: "all rules desired",
+			spec: avov1alpha2.SecurityGroup{
+				IngressRules: []avov1alpha2.SecurityGroupRule{
+					{FromPort: 443, ToPort: 443, Protocol: "tcp", CidrIp: "10.0.0.0/16"},
+					{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				},
+				EgressRules: []avov1alpha2.SecurityGroupRule{
+					{FromPort: -1, ToPort: -1, Protocol: "-1", CidrIp: "0.0.0.0/0"},
+				},
+			},
+		},
+		{
+			name: "removed rules",
+			spec: avov1alpha2.SecurityGroup{
+				IngressRules: []avov1alpha2.SecurityGroupRule{
+					{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				},
+			},
+			expected: []string{"sgr-cidr", "sgr-egress"},
+		},
+		{
+			name: "explicit sources",
+			spec: avov1alpha2.SecurityGroup{
+				IngressRules: []avov1alpha2.SecurityGroupRule{
+					{FromPort: 443, ToPort: 443, Protocol: "tcp", Cidrs: []string{"10.0.0.0/16"}, SourceSecurityGroupIds: []string{aws_client.MockSecurityGroupId}},
+				},
+				EgressRules: []avov1alpha2.SecurityGroupRule{
+					{FromPort: -1, ToPort: -1, Protocol: "-1", PrefixListIds: []string{"pl-0123"}},
+				},
+			},
+			expected: []string{"sgr-egress"},
+		},
+		{
+			name: "cluster security groups not found",
+			spec: avov1alpha2.SecurityGroup{
+				IngressRules: []avov1alpha2.SecurityGroupRule{
+					{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				},
+			},
+			clusterSgsNotFound: true,
+			expected:           []string{"sgr-cidr", "sgr-egress"},
+		},
+		{
+			name: "cluster security groups not found with explicit sources",
+			spec: avov1alpha2.SecurityGroup{
+				IngressRules: []avov1alpha2.SecurityGroupRule{
+					{FromPort: 443, ToPort: 443, Protocol: "tcp", CidrIp: "10.0.0.0/16"},
+				},
+			},
+			clusterSgsNotFound: true,
+			expected:           []string{"sgr-source", "sgr-egress"},
+		},
+		{
+			name:        "unmanaged rules",
+			annotations: map[string]string{unmanagedSecurityGroupRulesAnnotation: "sgr-egress, sgr-source"},
+			spec:        avov1alpha2.SecurityGroup{},
+			expected:    []string{"sgr-cidr"},
+		},
+		{
+			name:        "all rules unmanaged",
+			annotations: map[string]string{unmanagedSecurityGroupRulesAnnotation: "*"},
+			spec:        avov1alpha2.SecurityGroup{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: test.annotations,
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: test.spec,
+				},
+			}
+
+			sourceSgIds := []string{aws_client.MockSecurityGroupId}
+			if test.clusterSgsNotFound {
+				sourceSgIds = nil
+			}
+
+			var actual []string
+			for _, rule := range undesiredSecurityGroupRules(resource, awsRules, sourceSgIds) {
+				actual = append(actual, *rule.SecurityGroupRuleId)
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDescribeSecurityGroupRule(t *testing.T) {
+	assert.Equal(t, "sgr-12345 (egress tcp 443-443 10.0.0.0/16)", describeSecurityGroupRule(ec2Types.SecurityGroupRule{
+		SecurityGroupRuleId: aws.String("sgr-12345"),
+		CidrIpv4:            aws.String("10.0.0.0/16"),
+		FromPort:            aws.Int32(443),
+		IpProtocol:          aws.String("tcp"),
+		IsEgress:            aws.Bool(true),
+		ToPort:              aws.Int32(443),
+	}))
+}
+
+func TestMissingPermission(t *testing.T) {
+	awsRules := []ec2Types.SecurityGroupRule{
+		{
+			CidrIpv4:   aws.String("10.0.0.0/16"),
+			FromPort:   aws.Int32(443),
+			IpProtocol: aws.String("tcp"),
+			IsEgress:   aws.Bool(false),
+			ToPort:     aws.Int32(443),
+		},
+		{
+			CidrIpv6:   aws.String("2600:1f18::/56"),
+			FromPort:   aws.Int32(443),
+			IpProtocol: aws.String("tcp"),
+			IsEgress:   aws.Bool(true),
+			ToPort:     aws.Int32(443),
+		},
+		{
+			FromPort:   aws.Int32(443),
+			IpProtocol: aws.String("tcp"),
+			IsEgress:   aws.Bool(false),
+			ReferencedGroupInfo: &ec2Types.ReferencedSecurityGroup{
+				GroupId: aws.String("sg-0123"),
+				UserId:  aws.String("123456789012"),
+			},
+			ToPort: aws.Int32(443),
+		},
+		{
+			FromPort:     aws.Int32(443),
+			IpProtocol:   aws.String("tcp"),
+			IsEgress:     aws.Bool(false),
+			PrefixListId: aws.String("pl-0123"),
+			ToPort:       aws.Int32(443),
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		isEgress             bool
+		avoRule              avov1alpha2.SecurityGroupRule
+		expectedIpv4         int
+		expectedIpv6         int
+		expectedGroups       int
+		expectedPrefixLists  int
+		expectedDescriptions bool
+	}{
+		{
+			name: "ipv4 exists",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort: 443,
+				ToPort:   443,
+				Protocol: "tcp",
+				CidrIp:   "10.0.0.0/16",
+			},
+		},
+		{
+			name: "ipv6 missing",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort: 443,
+				ToPort:   443,
+				Protocol: "tcp",
+				CidrIp:   "10.0.0.0/16",
+				CidrIpv6: "2600:1f18::/56",
+			},
+			expectedIpv6: 1,
+		},
+		{
+			name:     "egress ipv6 exists",
+			isEgress: true,
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort: 443,
+				ToPort:   443,
+				Protocol: "tcp",
+				CidrIpv6: "2600:1f18::/56",
+			},
+		},
+		{
+			name:     "egress ipv4 missing",
+			isEgress: true,
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort: 443,
+				ToPort:   443,
+				Protocol: "tcp",
+				CidrIp:   "10.0.0.0/16",
+				CidrIpv6: "2600:1f18::/56",
+			},
+			expectedIpv4: 1,
+		},
+		{
+			name: "multiple cidrs",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort: 443,
+				ToPort:   443,
+				Protocol: "tcp",
+				CidrIp:   "10.0.0.0/16",
+				Cidrs:    []string{"10.0.0.0/16", "10.1.0.0/16", "10.2.0.0/16", "2600:1f18::/56"},
+			},
+			expectedIpv4: 2,
+			expectedIpv6: 1,
+		},
+		{
+			name: "source security groups",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort:               443,
+				ToPort:                 443,
+				Protocol:               "tcp",
+				SourceSecurityGroupIds: []string{"123456789012/sg-0123", "sg-4567", "210987654321/sg-0123"},
+			},
+			expectedGroups: 2,
+		},
+		{
+			name: "prefix lists",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort:      443,
+				ToPort:        443,
+				Protocol:      "tcp",
+				PrefixListIds: []string{"pl-0123", "pl-4567"},
+			},
+			expectedPrefixLists: 1,
+		},
+		{
+			name: "cluster security groups",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort: 443,
+				ToPort:   443,
+				Protocol: "tcp",
+			},
+			expectedGroups: 1,
+		},
+		{
+			name: "description",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort:               443,
+				ToPort:                 443,
+				Protocol:               "tcp",
+				Cidrs:                  []string{"10.1.0.0/16", "2600:1f18:1::/56"},
+				SourceSecurityGroupIds: []string{"sg-4567"},
+				PrefixListIds:          []string{"pl-4567"},
+				Description:            "mock",
+			},
+			expectedIpv4:         1,
+			expectedIpv6:         1,
+			expectedGroups:       1,
+			expectedPrefixLists:  1,
+			expectedDescriptions: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := missingPermission(test.isEgress, test.avoRule, awsRules, []string{aws_client.MockSecurityGroupId})
+			if test.expectedIpv4 == 0 && test.expectedIpv6 == 0 && test.expectedGroups == 0 && test.expectedPrefixLists == 0 {
+				assert.Nil(t, actual)
+				return
+			}
+
+			if assert.NotNil(t, actual) {
+				assert.Len(t, actual.IpRanges, test.expectedIpv4)
+				assert.Len(t, actual.Ipv6Ranges, test.expectedIpv6)
+				assert.Len(t, actual.UserIdGroupPairs, test.expectedGroups)
+				assert.Len(t, actual.PrefixListIds, test.expectedPrefixLists)
+
+				var descriptions []*string
+				for _, r := range actual.IpRanges {
+					descriptions = append(descriptions, r.Description)
+				}
+				for _, r := range actual.Ipv6Ranges {
+					descriptions = append(descriptions, r.Description)
+				}
+				for _, p := range actual.UserIdGroupPairs {
+					descriptions = append(descriptions, p.Description)
+				}
+				for _, p := range actual.PrefixListIds {
+					descriptions = append(descriptions, p.Description)
+				}
+				for _, description := range descriptions {
+					assert.Equal(t, test.expectedDescriptions, description != nil)
+				}
+			}
+		})
+	}
+}
+
 func TestVpcEndpointReconciler_findOrCreateVpcEndpoint(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -366,6 +716,7 @@ func TestVpcEndpointReconciler_findOrCreateVpcEndpoint(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equalf(t, "available", test.resource.Status.Status, "expected state to be %s, got %s", "available", test.resource.Status.Status)
+				assert.Equal(t, []string{aws_client.MockNetworkInterfaceId}, test.resource.Status.NetworkInterfaceIds)
 			}
 		})
 	}
@@ -393,12 +744,45 @@ func TestVpcEndpointReconciler_ensureVpcEndpointSubnets(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "gateway endpoints have no subnets",
+			vpce: &ec2Types.VpcEndpoint{
+				SubnetIds: nil,
+			},
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Type: string(ec2Types.VpcEndpointTypeGateway),
+					Vpc: avov1alpha2.Vpc{
+						SubnetIds: []string{aws_client.MockPrivateSubnetId},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "waiting for replaced network interfaces to be deleted",
+			vpce: &ec2Types.VpcEndpoint{
+				SubnetIds: nil,
+			},
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Vpc: avov1alpha2.Vpc{
+						SubnetIds: []string{aws_client.MockPrivateSubnetId},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					ReplacedNetworkInterfaceIds: []string{"eni-12345"},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			r := VpcEndpointReconciler{
-				log: testr.New(t),
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClientWithSubnets(),
 			}
 
 			err := r.ensureVpcEndpointSubnets(context.TODO(), test.vpce, test.resource)
@@ -415,82 +799,740 @@ func TestVpcEndpointReconciler_ensureVpcEndpointSubnets(t *testing.T) {
 	}
 }
 
-func TestVpcEndpointReconciler_diffVpcEndpointSecurityGroups(t *testing.T) {
+func TestVpcEndpointReconciler_expectedSubnetIds(t *testing.T) {
 	tests := []struct {
-		name                string
-		resource            *avov1alpha2.VpcEndpoint
-		vpce                *ec2Types.VpcEndpoint
-		expectedNumToAdd    int
-		expectedNumToRemove int
+		name      string
+		resource  *avov1alpha2.VpcEndpoint
+		expected  []string
+		expectErr bool
 	}{
 		{
-			name: "exact match",
+			name: "interface endpoint uses all subnets",
 			resource: &avov1alpha2.VpcEndpoint{
-				Status: avov1alpha2.VpcEndpointStatus{
-					SecurityGroupId: aws_client.MockSecurityGroupId,
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Vpc: avov1alpha2.Vpc{
+						SubnetIds: []string{"subnet-b", "subnet-a"},
+					},
 				},
 			},
-			vpce: &ec2Types.VpcEndpoint{
-				Groups: []ec2Types.SecurityGroupIdentifier{
-					{
-						GroupId: aws.String(aws_client.MockSecurityGroupId),
+			expected: []string{"subnet-b", "subnet-a"},
+		},
+		{
+			name: "gateway load balancer endpoint uses a single subnet",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Type: string(ec2Types.VpcEndpointTypeGatewayLoadBalancer),
+					Vpc: avov1alpha2.Vpc{
+						SubnetIds: []string{"subnet-b", "subnet-a"},
 					},
 				},
 			},
-			expectedNumToAdd:    0,
-			expectedNumToRemove: 0,
+			expected: []string{"subnet-a"},
 		},
 		{
-			name: "need to add and remove",
+			name: "dualstack endpoint in subnets with IPv6 CIDRs",
 			resource: &avov1alpha2.VpcEndpoint{
-				Status: avov1alpha2.VpcEndpointStatus{
-					SecurityGroupId: aws_client.MockSecurityGroupId,
+				Spec: avov1alpha2.VpcEndpointSpec{
+					IpAddressType: string(ec2Types.IpAddressTypeDualstack),
+					Vpc: avov1alpha2.Vpc{
+						SubnetIds: []string{aws_client.MockPrivateSubnetId},
+					},
 				},
 			},
-			vpce: &ec2Types.VpcEndpoint{
-				Groups: []ec2Types.SecurityGroupIdentifier{
-					{
-						GroupId: aws.String("sg-extra-to-remove"),
+			expected: []string{aws_client.MockPrivateSubnetId},
+		},
+		{
+			name: "ipv6 endpoint in subnets without IPv6 CIDRs",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					IpAddressType: string(ec2Types.IpAddressTypeIpv6),
+					Vpc: avov1alpha2.Vpc{
+						SubnetIds: []string{aws_client.MockPrivateSubnetId, aws_client.MockPublicSubnetId},
 					},
 				},
 			},
-			expectedNumToAdd:    1,
-			expectedNumToRemove: 1,
+			expectErr: true,
 		},
 	}
 
 	for _, test := range tests {
-		r := &VpcEndpointReconciler{
-			Client:      nil,
-			Scheme:      nil,
-			log:         testr.New(t),
-			awsClient:   nil,
-			clusterInfo: nil,
-		}
 		t.Run(test.name, func(t *testing.T) {
-			actualToAdd, actualToRemove, err := r.diffVpcEndpointSecurityGroups(test.vpce, test.resource)
+			r := &VpcEndpointReconciler{
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClientWithSubnets(),
+			}
 
-			assert.NoError(t, err)
-			assert.Equalf(t, test.expectedNumToAdd, len(actualToAdd), "expected to add %d, got %d", test.expectedNumToAdd, len(actualToAdd))
-			assert.Equalf(t, test.expectedNumToRemove, len(actualToRemove), "expected to remove %d, got %d", test.expectedNumToRemove, len(actualToRemove))
+			actual, err := r.expectedSubnetIds(context.TODO(), test.resource)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
 		})
 	}
 }
 
-func TestVpcEndpointReconciler_generateRoute53Record(t *testing.T) {
+func TestSelectSubnetPerAZ(t *testing.T) {
+	subnets := []ec2Types.Subnet{
+		{SubnetId: aws.String("subnet-c"), AvailabilityZone: aws.String("us-east-1a"), AvailableIpAddressCount: aws.Int32(100)},
+		{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-east-1a"), AvailableIpAddressCount: aws.Int32(10)},
+		{
+			SubnetId:                aws.String("subnet-b"),
+			AvailabilityZone:        aws.String("us-east-1a"),
+			AvailableIpAddressCount: aws.Int32(50),
+			Tags:                    []ec2Types.Tag{{Key: aws.String("preferred"), Value: aws.String("true")}},
+		},
+		{SubnetId: aws.String("subnet-d"), AvailabilityZone: aws.String("us-east-1b"), AvailableIpAddressCount: aws.Int32(10)},
+	}
+
 	tests := []struct {
-		resource  *avov1alpha2.VpcEndpoint
-		expectErr bool
+		name     string
+		vpc      avov1alpha2.Vpc
+		previous map[string]string
+		expected map[string]string
 	}{
 		{
-			resource: &avov1alpha2.VpcEndpoint{
-				Status: avov1alpha2.VpcEndpointStatus{
-					VPCEndpointId: testutil.MockVpcEndpointId,
-				},
-			},
-			expectErr: false,
+			name:     "LowestId by default",
+			expected: map[string]string{"us-east-1a": "subnet-a", "us-east-1b": "subnet-d"},
 		},
 		{
+			name:     "MostFreeIps",
+			vpc:      avov1alpha2.Vpc{SubnetSelectionPolicy: subnetSelectionPolicyMostFreeIps},
+			expected: map[string]string{"us-east-1a": "subnet-c", "us-east-1b": "subnet-d"},
+		},
+		{
+			name:     "MostFreeIps keeps previous selection",
+			vpc:      avov1alpha2.Vpc{SubnetSelectionPolicy: subnetSelectionPolicyMostFreeIps},
+			previous: map[string]string{"us-east-1a": "subnet-b", "us-east-1b": "subnet-gone"},
+			expected: map[string]string{"us-east-1a": "subnet-b", "us-east-1b": "subnet-d"},
+		},
+		{
+			name: "PreferredTag",
+			vpc: avov1alpha2.Vpc{
+				SubnetSelectionPolicy: subnetSelectionPolicyPreferredTag,
+				PreferredSubnetTag:    &avov1alpha2.Tag{Key: "preferred", Value: "true"},
+			},
+			expected: map[string]string{"us-east-1a": "subnet-b", "us-east-1b": "subnet-d"},
+		},
+		{
+			name: "PreferredTag key only",
+			vpc: avov1alpha2.Vpc{
+				SubnetSelectionPolicy: subnetSelectionPolicyPreferredTag,
+				PreferredSubnetTag:    &avov1alpha2.Tag{Key: "preferred"},
+			},
+			expected: map[string]string{"us-east-1a": "subnet-b", "us-east-1b": "subnet-d"},
+		},
+		{
+			name: "PreferredTag without a match falls back on LowestId",
+			vpc: avov1alpha2.Vpc{
+				SubnetSelectionPolicy: subnetSelectionPolicyPreferredTag,
+				PreferredSubnetTag:    &avov1alpha2.Tag{Key: "preferred", Value: "false"},
+			},
+			expected: map[string]string{"us-east-1a": "subnet-a", "us-east-1b": "subnet-d"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := selectSubnetPerAZ(slices.Clone(subnets), test.vpc, test.previous)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestSetServiceAZCoverageCondition(t *testing.T) {
+	// The AZ names of the subnets and VPC Endpoint Service differ, but they are in the same physical zones
+	serviceAZs := map[string]string{"use1-az1": "us-east-1b", "use1-az2": "us-east-1c"}
+
+	tests := []struct {
+		name           string
+		subnets        []ec2Types.Subnet
+		expectedStatus metav1.ConditionStatus
+		expectedMsg    string
+	}{
+		{
+			name: "all covered",
+			subnets: []ec2Types.Subnet{
+				{AvailabilityZone: aws.String("us-east-1a"), AvailabilityZoneId: aws.String("use1-az1")},
+				{AvailabilityZone: aws.String("us-east-1b"), AvailabilityZoneId: aws.String("use1-az2")},
+			},
+			expectedStatus: metav1.ConditionTrue,
+		},
+		{
+			name: "uncovered",
+			subnets: []ec2Types.Subnet{
+				{AvailabilityZone: aws.String("us-east-1b"), AvailabilityZoneId: aws.String("use1-az2")},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedMsg:    "use1-az1 (us-east-1b)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{}
+			setServiceAZCoverageCondition(resource, serviceAZs, test.subnets)
+
+			cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSServiceAZCoverageCondition)
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, test.expectedStatus, cond.Status)
+				assert.Contains(t, cond.Message, test.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_subnetConfigurations(t *testing.T) {
+	tests := []struct {
+		name      string
+		configs   []avov1alpha2.SubnetConfiguration
+		subnetIds []string
+		expected  []ec2Types.SubnetConfiguration
+		expectErr bool
+	}{
+		{
+			name:      "no configurations",
+			subnetIds: []string{aws_client.MockPrivateSubnetId},
+		},
+		{
+			name:      "configuration of an added subnet",
+			configs:   []avov1alpha2.SubnetConfiguration{{SubnetId: aws_client.MockPrivateSubnetId, Ipv4: "10.0.0.10"}},
+			subnetIds: []string{aws_client.MockPrivateSubnetId},
+			expected:  []ec2Types.SubnetConfiguration{{SubnetId: aws.String(aws_client.MockPrivateSubnetId), Ipv4: aws.String("10.0.0.10")}},
+		},
+		{
+			name:    "configuration of a subnet that's not being added",
+			configs: []avov1alpha2.SubnetConfiguration{{SubnetId: aws_client.MockPrivateSubnetId, Ipv4: "10.0.0.10"}},
+		},
+		{
+			name:      "address outside the subnet's CIDR block",
+			configs:   []avov1alpha2.SubnetConfiguration{{SubnetId: aws_client.MockPrivateSubnetId, Ipv4: "10.0.1.10"}},
+			subnetIds: []string{aws_client.MockPrivateSubnetId},
+			expectErr: true,
+		},
+		{
+			name:      "configuration of a subnet the VPC Endpoint isn't in",
+			configs:   []avov1alpha2.SubnetConfiguration{{SubnetId: aws_client.MockPublicSubnetId}},
+			subnetIds: []string{aws_client.MockPrivateSubnetId},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &VpcEndpointReconciler{
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClientWithSubnets(),
+			}
+			resource := &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Vpc: avov1alpha2.Vpc{SubnetConfigurations: test.configs},
+				},
+			}
+
+			actual, err := r.subnetConfigurations(context.TODO(), resource, []string{aws_client.MockPrivateSubnetId}, test.subnetIds)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_subnetsWithChangedAddresses(t *testing.T) {
+	tests := []struct {
+		name     string
+		configs  []avov1alpha2.SubnetConfiguration
+		expected []string
+	}{
+		{
+			name: "no configurations",
+		},
+		{
+			name:    "unchanged address",
+			configs: []avov1alpha2.SubnetConfiguration{{SubnetId: aws_client.MockPrivateSubnetId, Ipv4: "10.0.0.10"}},
+		},
+		{
+			name:     "changed address",
+			configs:  []avov1alpha2.SubnetConfiguration{{SubnetId: aws_client.MockPrivateSubnetId, Ipv4: "10.0.0.11"}},
+			expected: []string{aws_client.MockPrivateSubnetId},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &VpcEndpointReconciler{
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClientWithSubnets(),
+			}
+			resource := &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Vpc: avov1alpha2.Vpc{SubnetConfigurations: test.configs},
+				},
+			}
+			vpce := &ec2Types.VpcEndpoint{
+				NetworkInterfaceIds: []string{aws_client.MockNetworkInterfaceId},
+				SubnetIds:           []string{aws_client.MockPrivateSubnetId},
+			}
+
+			actual, err := r.subnetsWithChangedAddresses(context.TODO(), vpce, resource)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestContainsAddress(t *testing.T) {
+	assert.True(t, containsAddress([]string{"10.0.0.10", "2600:1f18::10"}, "2600:1f18:0:0::10"))
+	assert.False(t, containsAddress([]string{"10.0.0.10"}, "10.0.0.11"))
+}
+
+func TestVpcEndpointReconciler_ensureVpcEndpointIpAddressType(t *testing.T) {
+	tests := []struct {
+		name           string
+		vpce           *ec2Types.VpcEndpoint
+		resource       *avov1alpha2.VpcEndpoint
+		expectModified bool
+	}{
+		{
+			name: "unspecified",
+			vpce: &ec2Types.VpcEndpoint{
+				IpAddressType: ec2Types.IpAddressTypeIpv4,
+			},
+			resource: &avov1alpha2.VpcEndpoint{},
+		},
+		{
+			name: "in sync",
+			vpce: &ec2Types.VpcEndpoint{
+				IpAddressType: ec2Types.IpAddressTypeDualstack,
+			},
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					IpAddressType: string(ec2Types.IpAddressTypeDualstack),
+				},
+			},
+		},
+		{
+			name: "ipv4 to dualstack",
+			vpce: &ec2Types.VpcEndpoint{
+				IpAddressType: ec2Types.IpAddressTypeIpv4,
+			},
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					IpAddressType: string(ec2Types.IpAddressTypeDualstack),
+					DnsOptions: &avov1alpha2.DnsOptions{
+						DnsRecordIpType: string(ec2Types.DnsRecordIpTypeDualstack),
+					},
+				},
+			},
+			expectModified: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			r := &VpcEndpointReconciler{
+				Recorder:  recorder,
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClient(),
+			}
+			test.vpce.VpcEndpointId = aws.String(testutil.MockVpcEndpointId)
+
+			assert.NoError(t, r.ensureVpcEndpointIpAddressType(context.TODO(), test.vpce, test.resource))
+			assert.Equal(t, test.expectModified, len(recorder.Events) == 1)
+			if test.expectModified {
+				assert.False(t, dnsOptionsNeedUpdate(test.vpce.DnsOptions, test.resource.Spec.DnsOptions))
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_expectedRouteTableIds(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource *avov1alpha2.VpcEndpoint
+		expected []string
+	}{
+		{
+			name: "explicit route tables",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Type: string(ec2Types.VpcEndpointTypeGateway),
+					Vpc: avov1alpha2.Vpc{
+						RouteTableIds: []string{"rtb-explicit"},
+					},
+				},
+			},
+			expected: []string{"rtb-explicit"},
+		},
+		{
+			name: "discovered from the VPC",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Type: string(ec2Types.VpcEndpointTypeGateway),
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCId: aws_client.MockVpcId,
+				},
+			},
+			expected: []string{aws_client.MockRouteTableId},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &VpcEndpointReconciler{
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClient(),
+			}
+
+			actual, err := r.expectedRouteTableIds(context.TODO(), test.resource)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, test.expected, actual)
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_diffVpcEndpointSecurityGroups(t *testing.T) {
+	tests := []struct {
+		name                string
+		resource            *avov1alpha2.VpcEndpoint
+		vpce                *ec2Types.VpcEndpoint
+		expectedNumToAdd    int
+		expectedNumToRemove int
+		expectErr           bool
+	}{
+		{
+			name: "exact match",
+			resource: &avov1alpha2.VpcEndpoint{
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId: aws_client.MockSecurityGroupId,
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String(aws_client.MockSecurityGroupId),
+					},
+				},
+			},
+			expectedNumToAdd:    0,
+			expectedNumToRemove: 0,
+		},
+		{
+			name: "need to add and remove",
+			resource: &avov1alpha2.VpcEndpoint{
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId: aws_client.MockSecurityGroupId,
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String("sg-extra-to-remove"),
+					},
+				},
+			},
+			expectedNumToAdd:    1,
+			expectedNumToRemove: 1,
+		},
+		{
+			name: "additional security groups are kept",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						AdditionalSecurityGroupIds: []string{"sg-additional"},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId: "sg-managed",
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String("sg-managed"),
+					},
+					{
+						GroupId: aws.String("sg-additional"),
+					},
+				},
+			},
+			expectedNumToAdd:    0,
+			expectedNumToRemove: 0,
+		},
+		{
+			name: "additional security groups by tags",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						AdditionalSecurityGroupIds:  []string{"sg-additional"},
+						AdditionalSecurityGroupTags: []avov1alpha2.Tag{{Key: "mock"}},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId: "sg-managed",
+					VPCId:           aws_client.MockVpcId,
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String("sg-managed"),
+					},
+				},
+			},
+			expectedNumToAdd:    2,
+			expectedNumToRemove: 0,
+		},
+		{
+			name: "user-provided security groups only",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						Mode:                       securityGroupModeUserProvided,
+						AdditionalSecurityGroupIds: []string{"sg-additional"},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId: "sg-managed",
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String("sg-managed"),
+					},
+				},
+			},
+			expectedNumToAdd:    1,
+			expectedNumToRemove: 1,
+		},
+		{
+			name: "no user-provided security groups found",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						Mode:                        securityGroupModeUserProvided,
+						AdditionalSecurityGroupTags: []avov1alpha2.Tag{{Key: "mock", Value: "missing"}},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCId: aws_client.MockVpcId,
+				},
+			},
+			vpce:      &ec2Types.VpcEndpoint{},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		r := &VpcEndpointReconciler{
+			Client:      nil,
+			Scheme:      nil,
+			log:         testr.New(t),
+			awsClient:   aws_client.NewMockedAwsClient(),
+			clusterInfo: nil,
+		}
+		t.Run(test.name, func(t *testing.T) {
+			actualToAdd, actualToRemove, err := r.diffVpcEndpointSecurityGroups(context.TODO(), test.vpce, test.resource)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equalf(t, test.expectedNumToAdd, len(actualToAdd), "expected to add %d, got %d", test.expectedNumToAdd, len(actualToAdd))
+			assert.Equalf(t, test.expectedNumToRemove, len(actualToRemove), "expected to remove %d, got %d", test.expectedNumToRemove, len(actualToRemove))
+		})
+	}
+}
+
+func TestDnsOptionsNeedUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   *ec2Types.DnsOptions
+		desired  *avov1alpha2.DnsOptions
+		expected bool
+	}{
+		{
+			name:     "unmanaged",
+			actual:   &ec2Types.DnsOptions{DnsRecordIpType: ec2Types.DnsRecordIpTypeIpv4},
+			desired:  nil,
+			expected: false,
+		},
+		{
+			name:     "matching record ip type",
+			actual:   &ec2Types.DnsOptions{DnsRecordIpType: ec2Types.DnsRecordIpTypeDualstack},
+			desired:  &avov1alpha2.DnsOptions{DnsRecordIpType: "dualstack"},
+			expected: false,
+		},
+		{
+			name:     "different record ip type",
+			actual:   &ec2Types.DnsOptions{DnsRecordIpType: ec2Types.DnsRecordIpTypeIpv4},
+			desired:  &avov1alpha2.DnsOptions{DnsRecordIpType: "dualstack"},
+			expected: true,
+		},
+		{
+			name:     "missing dns options",
+			actual:   nil,
+			desired:  &avov1alpha2.DnsOptions{PrivateDnsOnlyForInboundResolverEndpoint: aws.Bool(false)},
+			expected: true,
+		},
+		{
+			name: "different inbound resolver endpoint only",
+			actual: &ec2Types.DnsOptions{
+				DnsRecordIpType:                          ec2Types.DnsRecordIpTypeIpv4,
+				PrivateDnsOnlyForInboundResolverEndpoint: aws.Bool(false),
+			},
+			desired:  &avov1alpha2.DnsOptions{PrivateDnsOnlyForInboundResolverEndpoint: aws.Bool(true)},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, dnsOptionsNeedUpdate(test.actual, test.desired))
+		})
+	}
+}
+
+func TestRoute53RecordName(t *testing.T) {
+	assert.Equal(t, "test.example.com", route53RecordName("test", "example.com."))
+	assert.Equal(t, "example.com", route53RecordName(zoneApexHostname, "example.com."))
+}
+
+func TestRoute53Records(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		Spec: avov1alpha2.VpcEndpointSpec{
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					Records: []avov1alpha2.Route53HostedZoneRecord{{Hostname: "api"}, {Hostname: "*.apps"}},
+				},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			ResourceRecordSet:  "test.example.com",
+			ResourceRecordSets: []string{"api.example.com"},
+		},
+	}
+	assert.Equal(t, []avov1alpha2.Route53HostedZoneRecord{{Hostname: "api"}, {Hostname: "*.apps"}}, route53Records(resource))
+	assert.Equal(t, []string{"api.example.com", "test.example.com"}, ownedRoute53RecordNames(resource))
+
+	resource.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname = "test"
+	assert.Equal(t, []avov1alpha2.Route53HostedZoneRecord{{Hostname: "test"}, {Hostname: "api"}, {Hostname: "*.apps"}}, route53Records(resource))
+}
+
+func TestVpcEndpointReconciler_generateRoute53Records(t *testing.T) {
+	tests := []struct {
+		name              string
+		resource          *avov1alpha2.VpcEndpoint
+		expected          []*route53Types.ResourceRecordSet
+		expectedAZRecords map[string][]string
+		expectErr         bool
+	}{
+		{
+			name: "CNAME with default TTL",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+							Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "test"},
+						},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId: testutil.MockVpcEndpointId,
+				},
+			},
+			expected: []*route53Types.ResourceRecordSet{
+				{
+					Name:            aws.String("test.example.com"),
+					Type:            route53Types.RRTypeCname,
+					TTL:             aws.Int64(defaultRecordTTL),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(testutil.MockVpcEndpointDnsName)}},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "multiple records",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+							Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "test", Type: "CNAME", TTL: 60},
+							Records: []avov1alpha2.Route53HostedZoneRecord{
+								{Hostname: "*.apps", Type: "AAAA"},
+							},
+						},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId: testutil.MockVpcEndpointId,
+				},
+			},
+			expected: []*route53Types.ResourceRecordSet{
+				{
+					Name:            aws.String("test.example.com"),
+					Type:            route53Types.RRTypeCname,
+					TTL:             aws.Int64(60),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(testutil.MockVpcEndpointDnsName)}},
+				},
+				{
+					Name: aws.String("*.apps.example.com"),
+					Type: route53Types.RRTypeAaaa,
+					AliasTarget: &route53Types.AliasTarget{
+						DNSName:      aws.String(testutil.MockVpcEndpointDnsName),
+						HostedZoneId: aws.String(testutil.MockVpcEndpointHostedZoneId),
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "availability zone affinity",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+							Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "test", Type: "A", AvailabilityZoneAffinity: true},
+						},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId: testutil.MockVpcEndpointId,
+				},
+			},
+			expected: []*route53Types.ResourceRecordSet{
+				{
+					Name: aws.String("test.example.com"),
+					Type: route53Types.RRTypeA,
+					AliasTarget: &route53Types.AliasTarget{
+						DNSName:      aws.String(testutil.MockVpcEndpointDnsName),
+						HostedZoneId: aws.String(testutil.MockVpcEndpointHostedZoneId),
+					},
+				},
+				{
+					Name: aws.String("us-gov-west-1a.test.example.com"),
+					Type: route53Types.RRTypeA,
+					AliasTarget: &route53Types.AliasTarget{
+						DNSName:      aws.String(testutil.MockVpcEndpointZonalDnsName),
+						HostedZoneId: aws.String(testutil.MockVpcEndpointHostedZoneId),
+					},
+				},
+			},
+			expectedAZRecords: map[string][]string{
+				"us-gov-west-1a": {"us-gov-west-1a.test.example.com"},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "missing VPC Endpoint ID",
 			resource:  &avov1alpha2.VpcEndpoint{},
 			expectErr: true,
 		},
@@ -510,13 +1552,125 @@ func TestVpcEndpointReconciler_generateRoute53Record(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err = r.generateRoute53Record(context.TODO(), test.resource)
-		if test.expectErr {
-			assert.Error(t, err)
-		} else {
-			assert.NoError(t, err)
-		}
+		t.Run(test.name, func(t *testing.T) {
+			actual, azRecords, err := r.generateRoute53Records(context.TODO(), test.resource, "example.com.")
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+				assert.Equal(t, test.expectedAZRecords, azRecords)
+			}
+		})
+	}
+}
+
+func TestRoute53RecordUpToDate(t *testing.T) {
+	cname := &route53Types.ResourceRecordSet{
+		Name:            aws.String("test.example.com"),
+		Type:            route53Types.RRTypeCname,
+		TTL:             aws.Int64(300),
+		ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String("vpce-12345.amazonaws.com")}},
+	}
+	alias := &route53Types.ResourceRecordSet{
+		Name: aws.String("test.example.com"),
+		Type: route53Types.RRTypeA,
+		AliasTarget: &route53Types.AliasTarget{
+			DNSName:      aws.String("VPCE-12345.amazonaws.com"),
+			HostedZoneId: aws.String(testutil.MockVpcEndpointHostedZoneId),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		existing []route53Types.ResourceRecordSet
+		desired  *route53Types.ResourceRecordSet
+		expected bool
+	}{
+		{
+			name:     "missing",
+			desired:  cname,
+			expected: false,
+		},
+		{
+			name: "CNAME up to date",
+			existing: []route53Types.ResourceRecordSet{
+				{
+					Name:            aws.String("test.example.com."),
+					Type:            route53Types.RRTypeCname,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String("vpce-12345.amazonaws.com")}},
+				},
+			},
+			desired:  cname,
+			expected: true,
+		},
+		{
+			name: "CNAME TTL changed",
+			existing: []route53Types.ResourceRecordSet{
+				{
+					Name:            aws.String("test.example.com."),
+					Type:            route53Types.RRTypeCname,
+					TTL:             aws.Int64(60),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String("vpce-12345.amazonaws.com")}},
+				},
+			},
+			desired:  cname,
+			expected: false,
+		},
+		{
+			name: "alias up to date",
+			existing: []route53Types.ResourceRecordSet{
+				{
+					Name: aws.String("test.example.com."),
+					Type: route53Types.RRTypeA,
+					AliasTarget: &route53Types.AliasTarget{
+						DNSName:      aws.String("vpce-12345.amazonaws.com."),
+						HostedZoneId: aws.String(testutil.MockVpcEndpointHostedZoneId),
+					},
+				},
+			},
+			desired:  alias,
+			expected: true,
+		},
+		{
+			name:     "type changed",
+			existing: []route53Types.ResourceRecordSet{*cname},
+			desired:  alias,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, route53RecordUpToDate(test.existing, test.desired))
+		})
+	}
+}
+
+func TestZonalDnsEntries(t *testing.T) {
+	dnsEntries := []ec2Types.DnsEntry{
+		{DnsName: aws.String("vpce-0123-abcd.vpce-svc-0123.us-east-1.vpce.amazonaws.com")},
+		{DnsName: aws.String("vpce-0123-abcd-us-east-1a.vpce-svc-0123.us-east-1.vpce.amazonaws.com")},
+		{DnsName: aws.String("vpce-0123-abcd-us-east-1b.vpce-svc-0123.us-east-1.vpce.amazonaws.com")},
+		{DnsName: aws.String("example.com")},
 	}
+
+	assert.Equal(t, map[string]ec2Types.DnsEntry{
+		"us-east-1a": dnsEntries[1],
+		"us-east-1b": dnsEntries[2],
+	}, zonalDnsEntries(dnsEntries))
+	assert.Empty(t, zonalDnsEntries(nil))
+}
+
+func TestStaleRoute53Records(t *testing.T) {
+	existing := []route53Types.ResourceRecordSet{
+		{Name: aws.String("test.example.com."), Type: route53Types.RRTypeCname},
+		{Name: aws.String("test.example.com."), Type: route53Types.RRTypeTxt},
+	}
+
+	assert.Empty(t, staleRoute53Records(existing, &route53Types.ResourceRecordSet{Type: route53Types.RRTypeCname}))
+	assert.Equal(t, existing[:1], staleRoute53Records(existing, &route53Types.ResourceRecordSet{Type: route53Types.RRTypeA}))
 }
 
 func TestVpcEndpointReconciler_generateExternalNameService(t *testing.T) {
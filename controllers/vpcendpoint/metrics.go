@@ -45,8 +45,30 @@ var (
 			"action",
 		},
 	)
+
+	vpcEndpointQuotaExceeded = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "aws_vpce_operator",
+			Name:      "vpc_endpoint_quota_exceeded_total",
+			Help:      "Count of VPC Endpoints rejected by AWS because their VPC reached its VPC Endpoint quota, labeled by AWS VPC ID",
+		},
+		[]string{
+			"vpc_id",
+		},
+	)
+
+	subnetFreeIpAddresses = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "aws_vpce_operator",
+			Name:      "subnet_free_ip_addresses",
+			Help:      "Number of free IP addresses in the subnets selected for VPC Endpoints, labeled by AWS subnet ID",
+		},
+		[]string{
+			"subnet_id",
+		},
+	)
 )
 
 func init() {
-	metrics.Registry.MustRegister(vpcePendingAcceptance, awsUnauthorizedOperation)
+	metrics.Registry.MustRegister(vpcePendingAcceptance, awsUnauthorizedOperation, vpcEndpointQuotaExceeded, subnetFreeIpAddresses)
 }
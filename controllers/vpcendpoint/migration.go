@@ -0,0 +1,154 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/smithy-go"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// startVpcMigration records the VPC Endpoint and security group in the current VPC in .status.migration and resets
+// the status so that new ones are created in vpcId, with reason explaining why in the AWSVpcMigrationComplete
+// condition. The status is not persisted, that is left to the caller.
+func startVpcMigration(resource *avov1alpha2.VpcEndpoint, vpcId, reason string) {
+	resource.Status.Migration = &avov1alpha2.VpcMigrationStatus{
+		VPCId:           resource.Status.VPCId,
+		VPCEndpointId:   resource.Status.VPCEndpointId,
+		SecurityGroupId: resource.Status.SecurityGroupId,
+	}
+	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+		Type:    avov1alpha2.AWSVpcMigrationCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: fmt.Sprintf("Migrating from %s to %s", resource.Status.VPCId, vpcId),
+	})
+
+	resource.Status.VPCId = vpcId
+	resource.Status.VPCEndpointId = ""
+	resource.Status.SecurityGroupId = ""
+	resource.Status.NetworkInterfaceIds = nil
+	resource.Status.ReplacedNetworkInterfaceIds = nil
+	resource.Status.AdditionalSecurityGroupIds = nil
+	resource.Status.Status = ""
+}
+
+// validateVpcMigration completes a migration to another VPC by deleting the VPC Endpoint and security group in the
+// previous VPC once the VPC Endpoint in the new VPC is available and custom DNS has been pointed to it
+func (r *VpcEndpointReconciler) validateVpcMigration(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	if resource == nil {
+		// Should never happen
+		return errors.New("resource must be specified")
+	}
+
+	if resource.Status.Migration == nil {
+		return nil
+	}
+
+	from, to := resource.Status.Migration.VPCId, resource.Status.VPCId
+	if resource.Status.Status != "available" {
+		r.log.V(1).Info("Waiting for the VPC Endpoint in the new VPC to be available", "from", from, "to", to)
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSVpcMigrationCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Migrating",
+			Message: fmt.Sprintf("Waiting for the VPC Endpoint in %s to be available before deleting the one in %s", to, from),
+		})
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+
+		return nil
+	}
+
+	if err := r.cleanupVpcMigration(ctx, resource); err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "DependencyViolation" {
+			// The security group can't be deleted until the previous VPC Endpoint's network interfaces are gone
+			return &requeueAfterError{after: migrationRequeueDelay, reason: "waiting for the VPC Endpoint in the previous VPC to be deleted"}
+		}
+
+		return err
+	}
+
+	r.log.V(0).Info("Migrated VPC Endpoint", "from", from, "to", to)
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Migrated", "Migrated VPC endpoint from %s to %s", from, to)
+	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+		Type:    avov1alpha2.AWSVpcMigrationCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Migrated",
+		Message: fmt.Sprintf("Migrated from %s to %s", from, to),
+	})
+	if err := r.Status().Update(ctx, resource); err != nil {
+		r.log.V(0).Error(err, "failed to update status")
+		return err
+	}
+
+	return nil
+}
+
+// cleanupVpcMigration deletes the VPC Endpoint and security group left in the previous VPC by a migration, if any,
+// and clears .status.migration once both are gone
+func (r *VpcEndpointReconciler) cleanupVpcMigration(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	migration := resource.Status.Migration
+	if migration == nil {
+		return nil
+	}
+
+	if migration.VPCEndpointId != "" {
+		r.log.V(0).Info("Deleting VPC Endpoint in previous VPC", "id", migration.VPCEndpointId, "vpcId", migration.VPCId)
+		if _, err := r.awsClient.DeleteVPCEndpoint(ctx, migration.VPCEndpointId); err != nil {
+			var ae smithy.APIError
+			if !errors.As(err, &ae) || ae.ErrorCode() != "InvalidVpcEndpoint.NotFound" {
+				return err
+			}
+		}
+
+		vpcePendingAcceptance.DeleteLabelValues(resource.Name, resource.Namespace, migration.VPCEndpointId)
+		migration.VPCEndpointId = ""
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+	}
+
+	if migration.SecurityGroupId != "" {
+		r.log.V(0).Info("Deleting security group in previous VPC", "id", migration.SecurityGroupId, "vpcId", migration.VPCId)
+		if _, err := r.awsClient.DeleteSecurityGroup(ctx, migration.SecurityGroupId); err != nil {
+			var ae smithy.APIError
+			if !errors.As(err, &ae) || ae.ErrorCode() != "InvalidGroup.NotFound" {
+				return err
+			}
+		}
+
+		migration.SecurityGroupId = ""
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+	}
+
+	resource.Status.Migration = nil
+	return nil
+}
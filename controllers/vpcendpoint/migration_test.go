@@ -0,0 +1,239 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr/testr"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestVpcEndpointReconciler_candidateVpcIds(t *testing.T) {
+	tests := []struct {
+		name         string
+		endpointType string
+		vpc          avov1alpha2.Vpc
+		expected     []string
+		expectErr    bool
+	}{
+		{
+			name:     "ids",
+			vpc:      avov1alpha2.Vpc{Ids: []string{"vpc-1", "vpc-2"}},
+			expected: []string{"vpc-1", "vpc-2"},
+		},
+		{
+			name:     "subnet ids",
+			vpc:      avov1alpha2.Vpc{SubnetIds: []string{aws_client.MockPrivateSubnetId}},
+			expected: []string{aws_client.MockVpcId},
+		},
+		{
+			name:      "unknown subnet ids",
+			vpc:       avov1alpha2.Vpc{SubnetIds: []string{"subnet-unknown"}},
+			expectErr: true,
+		},
+		{
+			name:         "gateway route table ids",
+			endpointType: string(ec2Types.VpcEndpointTypeGateway),
+			vpc:          avov1alpha2.Vpc{RouteTableIds: []string{aws_client.MockRouteTableId}},
+			expected:     []string{aws_client.MockVpcId},
+		},
+		{
+			name:         "gateway route table tags",
+			endpointType: string(ec2Types.VpcEndpointTypeGateway),
+			vpc:          avov1alpha2.Vpc{RouteTableTags: []avov1alpha2.Tag{{Key: "mock", Value: ""}}},
+			expected:     []string{aws_client.MockVpcId},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &VpcEndpointReconciler{
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClientWithSubnets(),
+			}
+
+			actual, err := r.candidateVpcIds(context.TODO(), &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Type: test.endpointType,
+					Vpc:  test.vpc,
+				},
+			})
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_placeVpcEndpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  string
+		quota     int32
+		expectErr bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:     "RoundRobin",
+			strategy: aws_client.PlacementStrategyRoundRobin,
+		},
+		{
+			name:     "QuotaHeadroom",
+			strategy: aws_client.PlacementStrategyQuotaHeadroom,
+			quota:    1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &VpcEndpointReconciler{
+				log:               testr.New(t),
+				awsClient:         aws_client.NewMockedAwsClient(),
+				roundRobin:        new(aws_client.RoundRobinStrategy),
+				vpcQuotaCooldowns: new(vpcQuotaCooldowns),
+			}
+
+			placement, err := r.placeVpcEndpoint(context.TODO(), &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Vpc: avov1alpha2.Vpc{
+						PlacementStrategy: test.strategy,
+						EndpointQuota:     test.quota,
+					},
+				},
+			}, []string{"vpc-1", "vpc-2"})
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "vpc-1", placement.VpcId)
+				assert.NotEmpty(t, placement.Reason)
+			}
+		})
+	}
+}
+
+func TestStartVpcMigration(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		Status: avov1alpha2.VpcEndpointStatus{
+			VPCId:               aws_client.MockVpcId,
+			VPCEndpointId:       testutil.MockVpcEndpointId,
+			SecurityGroupId:     aws_client.MockSecurityGroupId,
+			NetworkInterfaceIds: []string{aws_client.MockNetworkInterfaceId},
+			Status:              "available",
+		},
+	}
+
+	startVpcMigration(resource, "vpc-new", "Migrating")
+
+	assert.Equal(t, &avov1alpha2.VpcMigrationStatus{
+		VPCId:           aws_client.MockVpcId,
+		VPCEndpointId:   testutil.MockVpcEndpointId,
+		SecurityGroupId: aws_client.MockSecurityGroupId,
+	}, resource.Status.Migration)
+	assert.Equal(t, "vpc-new", resource.Status.VPCId)
+	assert.Empty(t, resource.Status.VPCEndpointId)
+	assert.Empty(t, resource.Status.SecurityGroupId)
+	assert.Empty(t, resource.Status.NetworkInterfaceIds)
+	assert.Empty(t, resource.Status.Status)
+	assert.True(t, meta.IsStatusConditionFalse(resource.Status.Conditions, avov1alpha2.AWSVpcMigrationCondition))
+}
+
+func TestVpcEndpointReconciler_validateVpcMigration(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          string
+		migration       *avov1alpha2.VpcMigrationStatus
+		expectMigrated  bool
+		expectCondition bool
+	}{
+		{
+			name: "not migrating",
+		},
+		{
+			name:   "new VPC Endpoint not available",
+			status: "pendingAcceptance",
+			migration: &avov1alpha2.VpcMigrationStatus{
+				VPCId:           aws_client.MockVpcId,
+				VPCEndpointId:   testutil.MockVpcEndpointId,
+				SecurityGroupId: aws_client.MockSecurityGroupId,
+			},
+			expectCondition: true,
+		},
+		{
+			name:   "new VPC Endpoint available",
+			status: "available",
+			migration: &avov1alpha2.VpcMigrationStatus{
+				VPCId:           aws_client.MockVpcId,
+				VPCEndpointId:   testutil.MockVpcEndpointId,
+				SecurityGroupId: aws_client.MockSecurityGroupId,
+			},
+			expectMigrated:  true,
+			expectCondition: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mock",
+					Namespace: "mock",
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCId:     "vpc-new",
+					Status:    test.status,
+					Migration: test.migration,
+				},
+			}
+
+			recorder := record.NewFakeRecorder(1)
+			r := &VpcEndpointReconciler{
+				Client:    testutil.NewTestMock(t, resource).Client,
+				Recorder:  recorder,
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClient(),
+			}
+
+			assert.NoError(t, r.validateVpcMigration(context.TODO(), resource))
+			assert.Equal(t, test.expectMigrated, len(recorder.Events) == 1)
+			assert.Equal(t, test.expectMigrated, resource.Status.Migration == nil && test.migration != nil)
+
+			cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSVpcMigrationCondition)
+			if !test.expectCondition {
+				assert.Nil(t, cond)
+				return
+			}
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, test.expectMigrated, cond.Status == metav1.ConditionTrue)
+			}
+		})
+	}
+}
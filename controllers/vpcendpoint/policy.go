@@ -0,0 +1,138 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errInvalidPolicy indicates that .spec.policy cannot be used until the user fixes it, so retrying is pointless
+var errInvalidPolicy = errors.New("invalid VPC Endpoint policy")
+
+// vpcEndpointPolicyDocument returns the JSON policy document specified by .spec.policy, reading it from a ConfigMap if
+// needed, or an empty string if .spec.policy is unspecified. Errors wrapping errInvalidPolicy require user action.
+func (r *VpcEndpointReconciler) vpcEndpointPolicyDocument(ctx context.Context, resource *avov1alpha2.VpcEndpoint) (string, error) {
+	policy := resource.Spec.Policy
+	if policy == nil {
+		return "", nil
+	}
+
+	document := policy.Document
+	if ref := policy.ConfigMapKeyRef; ref != nil {
+		cm := new(corev1.ConfigMap)
+		// We use an APIReader instead of reading from the cache so that the controller doesn't watch every ConfigMap
+		if err := r.APIReader.Get(ctx, client.ObjectKey{Namespace: resource.Namespace, Name: ref.Name}, cm); err != nil {
+			if kerr.IsNotFound(err) {
+				return "", fmt.Errorf("%w: ConfigMap %s/%s not found", errInvalidPolicy, resource.Namespace, ref.Name)
+			}
+
+			return "", err
+		}
+
+		var ok bool
+		document, ok = cm.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("%w: key %s not found in ConfigMap %s/%s", errInvalidPolicy, ref.Key, resource.Namespace, ref.Name)
+		}
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(document), &parsed); err != nil {
+		return "", fmt.Errorf("%w: policy document is not a JSON object: %v", errInvalidPolicy, err)
+	}
+
+	return document, nil
+}
+
+// policyDocumentsEqual returns true if the two JSON policy documents are semantically equal, ignoring formatting
+func policyDocumentsEqual(a, b string) bool {
+	var parsedA, parsedB any
+	if err := json.Unmarshal([]byte(a), &parsedA); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &parsedB); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(parsedA, parsedB)
+}
+
+// ensureVpcEndpointPolicy ensures that the policy of the VPC Endpoint matches .spec.policy and updates the
+// AWSVpcEndpointPolicyReady condition. The status is not persisted, that is left to the caller.
+func (r *VpcEndpointReconciler) ensureVpcEndpointPolicy(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	document, err := r.vpcEndpointPolicyDocument(ctx, resource)
+	if err != nil {
+		if errors.Is(err, errInvalidPolicy) {
+			r.log.V(0).Info("Not reconciling VPC Endpoint policy", "error", err.Error())
+			setPolicyCondition(resource, err)
+			return nil
+		}
+
+		return err
+	}
+
+	if resource.Spec.Policy != nil && !policyDocumentsEqual(aws.ToString(vpce.PolicyDocument), document) {
+		r.log.V(0).Info("Updating VPC Endpoint policy", "id", *vpce.VpcEndpointId)
+		if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
+			VpcEndpointId:  vpce.VpcEndpointId,
+			PolicyDocument: aws.String(document),
+		}); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Modified", "Modified policy of VPC endpoint: %s", *vpce.VpcEndpointId)
+	}
+
+	setPolicyCondition(resource, nil)
+	return nil
+}
+
+// setPolicyCondition reflects whether .spec.policy could be applied in the resource's status conditions.
+// The status is not persisted, that is left to the caller.
+func setPolicyCondition(resource *avov1alpha2.VpcEndpoint, err error) {
+	switch {
+	case err != nil:
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSVpcEndpointPolicyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidPolicy",
+			Message: err.Error(),
+		})
+	case resource.Spec.Policy == nil:
+		meta.RemoveStatusCondition(&resource.Status.Conditions, avov1alpha2.AWSVpcEndpointPolicyCondition)
+	default:
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSVpcEndpointPolicyCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Applied",
+			Message: "VPC Endpoint policy is up to date",
+		})
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr/testr"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const mockPolicyDocument = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::mock/*"}]}`
+
+func TestPolicyDocumentsEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{
+			name:     "identical",
+			a:        mockPolicyDocument,
+			b:        mockPolicyDocument,
+			expected: true,
+		},
+		{
+			name: "different formatting",
+			a:    mockPolicyDocument,
+			b: `{
+  "Statement": [{"Resource": "arn:aws:s3:::mock/*", "Action": "s3:GetObject", "Principal": "*", "Effect": "Allow"}],
+  "Version": "2012-10-17"
+}`,
+			expected: true,
+		},
+		{
+			name:     "different statements",
+			a:        mockPolicyDocument,
+			b:        `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`,
+			expected: false,
+		},
+		{
+			name:     "no live policy",
+			a:        "",
+			b:        mockPolicyDocument,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, policyDocumentsEqual(test.a, test.b))
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_vpcEndpointPolicyDocument(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "policy",
+			Namespace: "mock",
+		},
+		Data: map[string]string{
+			"policy.json": mockPolicyDocument,
+			"invalid":     "not json",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		policy    *avov1alpha2.Policy
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "unspecified",
+			expected: "",
+		},
+		{
+			name:     "inline",
+			policy:   &avov1alpha2.Policy{Document: mockPolicyDocument},
+			expected: mockPolicyDocument,
+		},
+		{
+			name:      "inline invalid JSON",
+			policy:    &avov1alpha2.Policy{Document: `{"Version":`},
+			expectErr: true,
+		},
+		{
+			name: "ConfigMap",
+			policy: &avov1alpha2.Policy{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "policy"},
+				Key:                  "policy.json",
+			}},
+			expected: mockPolicyDocument,
+		},
+		{
+			name: "ConfigMap invalid JSON",
+			policy: &avov1alpha2.Policy{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "policy"},
+				Key:                  "invalid",
+			}},
+			expectErr: true,
+		},
+		{
+			name: "ConfigMap missing key",
+			policy: &avov1alpha2.Policy{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "policy"},
+				Key:                  "missing",
+			}},
+			expectErr: true,
+		},
+		{
+			name: "ConfigMap not found",
+			policy: &avov1alpha2.Policy{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "policy.json",
+			}},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &VpcEndpointReconciler{
+				APIReader: testutil.NewTestMock(t, configMap).Client,
+				log:       testr.New(t),
+			}
+
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mock",
+					Namespace: "mock",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Policy: test.policy,
+				},
+			}
+
+			actual, err := r.vpcEndpointPolicyDocument(context.TODO(), resource)
+			if test.expectErr {
+				assert.ErrorIs(t, err, errInvalidPolicy)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_ensureVpcEndpointPolicy(t *testing.T) {
+	tests := []struct {
+		name              string
+		policy            *avov1alpha2.Policy
+		livePolicy        string
+		expectModified    bool
+		expectedCondition metav1.ConditionStatus
+	}{
+		{
+			name:       "unmanaged",
+			livePolicy: mockPolicyDocument,
+		},
+		{
+			name:              "in sync",
+			policy:            &avov1alpha2.Policy{Document: mockPolicyDocument},
+			livePolicy:        mockPolicyDocument,
+			expectedCondition: metav1.ConditionTrue,
+		},
+		{
+			name:              "drift",
+			policy:            &avov1alpha2.Policy{Document: mockPolicyDocument},
+			livePolicy:        `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`,
+			expectModified:    true,
+			expectedCondition: metav1.ConditionTrue,
+		},
+		{
+			name:              "invalid",
+			policy:            &avov1alpha2.Policy{Document: "not json"},
+			livePolicy:        mockPolicyDocument,
+			expectedCondition: metav1.ConditionFalse,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			r := &VpcEndpointReconciler{
+				APIReader: testutil.NewTestMock(t).Client,
+				Recorder:  recorder,
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClient(),
+			}
+
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mock",
+					Namespace: "mock",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Policy: test.policy,
+				},
+			}
+
+			vpce := &ec2Types.VpcEndpoint{
+				VpcEndpointId:  aws.String(testutil.MockVpcEndpointId),
+				PolicyDocument: aws.String(test.livePolicy),
+			}
+
+			assert.NoError(t, r.ensureVpcEndpointPolicy(context.TODO(), vpce, resource))
+			assert.Equal(t, test.expectModified, len(recorder.Events) == 1)
+
+			cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointPolicyCondition)
+			if test.expectedCondition == "" {
+				assert.Nil(t, cond)
+			} else if assert.NotNil(t, cond) {
+				assert.Equal(t, test.expectedCondition, cond.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// vpcQuotaCooldowns records VPCs that recently rejected a VPC Endpoint because they reached their VPC Endpoint quota,
+// so that they are skipped when placing load balanced VPC Endpoints until their cooldown expires. It is only kept in
+// memory, so every VPC is considered again when AVO restarts.
+type vpcQuotaCooldowns struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+// markFull marks vpcId as full until the given time
+func (c *vpcQuotaCooldowns) markFull(vpcId string, until time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.until == nil {
+		c.until = map[string]time.Time{}
+	}
+	c.until[vpcId] = until
+}
+
+// available returns the VPC ids that are not cooling down at now. If there are none, it also returns how long until
+// the first one is available again.
+func (c *vpcQuotaCooldowns) available(vpcIds []string, now time.Time) ([]string, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		available []string
+		wait      time.Duration
+	)
+	for _, vpcId := range vpcIds {
+		until, ok := c.until[vpcId]
+		if !ok || !now.Before(until) {
+			delete(c.until, vpcId)
+			available = append(available, vpcId)
+			continue
+		}
+
+		if remaining := until.Sub(now); wait == 0 || remaining < wait {
+			wait = remaining
+		}
+	}
+
+	if len(available) > 0 {
+		return available, 0
+	}
+
+	return nil, wait
+}
+
+// isLoadBalanced returns true if the VPC Endpoint can be placed in one of several VPCs
+func isLoadBalanced(resource *avov1alpha2.VpcEndpoint) bool {
+	return len(resource.Spec.Vpc.Ids) > 0 || len(resource.Spec.Vpc.Tags) > 0
+}
+
+// fallBackFromFullVpc handles createErr, AWS rejecting the VPC Endpoint because .status.vpcId reached its VPC Endpoint
+// quota. Load balanced VPC Endpoints are placed in another VPC instead, deleting the managed security group in the
+// full VPC so that it's recreated in the new one, while the full VPC is skipped for a cooldown period.
+func (r *VpcEndpointReconciler) fallBackFromFullVpc(ctx context.Context, resource *avov1alpha2.VpcEndpoint, createErr error) error {
+	fullVpcId := resource.Status.VPCId
+	vpcEndpointQuotaExceeded.WithLabelValues(fullVpcId).Inc()
+	if !isLoadBalanced(resource) {
+		return fmt.Errorf("failed to create vpc endpoint: %w", createErr)
+	}
+
+	r.vpcQuotaCooldowns.markFull(fullVpcId, time.Now().Add(vpcQuotaCooldown))
+	r.log.V(0).Info("VPC reached its VPC Endpoint quota", "vpcId", fullVpcId, "cooldown", vpcQuotaCooldown.String())
+
+	vpcIds, err := r.candidateVpcIds(ctx, resource)
+	if err != nil {
+		return err
+	}
+
+	// Never fall back to the VPC being migrated away from, it still has the VPC Endpoint that's being replaced
+	vpcIds = slices.DeleteFunc(slices.Clone(vpcIds), func(vpcId string) bool {
+		return resource.Status.Migration != nil && vpcId == resource.Status.Migration.VPCId
+	})
+
+	available, wait := r.vpcQuotaCooldowns.available(vpcIds, time.Now())
+	if len(available) == 0 {
+		message := fmt.Sprintf("All VPCs %v reached their VPC Endpoint quota", vpcIds)
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSVpcEndpointCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "VpcEndpointLimitExceeded",
+			Message: message,
+		})
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+
+		if wait == 0 {
+			// Only the VPC being migrated away from is left
+			wait = vpcQuotaCooldown
+		}
+
+		return &requeueAfterError{after: wait, reason: message}
+	}
+
+	placement, err := r.placeVpcEndpoint(ctx, resource, available)
+	if err != nil {
+		return fmt.Errorf("failed to select a VPC to place a VPC Endpoint in: %w", err)
+	}
+
+	if resource.Status.SecurityGroupId != "" {
+		r.log.V(0).Info("Deleting security group in full VPC", "id", resource.Status.SecurityGroupId, "vpcId", fullVpcId)
+		if _, err := r.awsClient.DeleteSecurityGroup(ctx, resource.Status.SecurityGroupId); err != nil {
+			var ae smithy.APIError
+			switch {
+			case errors.As(err, &ae) && ae.ErrorCode() == "InvalidGroup.NotFound":
+				// Already deleted, e.g. by a previous attempt whose status update failed
+			case errors.As(err, &ae) && ae.ErrorCode() == "DependencyViolation":
+				return &requeueAfterError{after: migrationRequeueDelay, reason: fmt.Sprintf("waiting to delete security group %s in full VPC %s", resource.Status.SecurityGroupId, fullVpcId)}
+			default:
+				return err
+			}
+		}
+	}
+
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "VpcEndpointLimitExceeded", "%s reached its VPC Endpoint quota, placing VPC Endpoint in %s instead",
+		fullVpcId, placement.VpcId)
+	resource.Status.VPCId = placement.VpcId
+	resource.Status.PlacementReason = fmt.Sprintf("%s reached its VPC Endpoint quota, %s", fullVpcId, placement.Reason)
+	resource.Status.SecurityGroupId = ""
+	resource.Status.AdditionalSecurityGroupIds = nil
+	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+		Type:    avov1alpha2.AWSVpcEndpointCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "VpcEndpointLimitExceeded",
+		Message: resource.Status.PlacementReason,
+	})
+	if err := r.Status().Update(ctx, resource); err != nil {
+		r.log.V(0).Error(err, "failed to update status")
+		return err
+	}
+
+	return &requeueAfterError{after: time.Second, reason: fmt.Sprintf("placing VPC Endpoint in %s", placement.VpcId)}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/go-logr/logr/testr"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestVpcQuotaCooldowns_available(t *testing.T) {
+	now := time.Now()
+	cooldowns := new(vpcQuotaCooldowns)
+	cooldowns.markFull("vpc-1", now.Add(time.Minute))
+	cooldowns.markFull("vpc-2", now.Add(time.Minute*2))
+
+	available, wait := cooldowns.available([]string{"vpc-1", "vpc-2", "vpc-3"}, now)
+	assert.Equal(t, []string{"vpc-3"}, available)
+	assert.Zero(t, wait)
+
+	available, wait = cooldowns.available([]string{"vpc-1", "vpc-2"}, now)
+	assert.Empty(t, available)
+	assert.Equal(t, time.Minute, wait)
+
+	// vpc-1's cooldown expired
+	available, _ = cooldowns.available([]string{"vpc-1", "vpc-2"}, now.Add(time.Minute))
+	assert.Equal(t, []string{"vpc-1"}, available)
+}
+
+func TestVpcEndpointReconciler_fallBackFromFullVpc(t *testing.T) {
+	tests := []struct {
+		name          string
+		vpc           avov1alpha2.Vpc
+		fullVpcIds    []string
+		expectedVpcId string
+		expectRequeue bool
+		expectEvent   bool
+	}{
+		{
+			name:          "not load balanced",
+			vpc:           avov1alpha2.Vpc{SubnetIds: []string{aws_client.MockPrivateSubnetId}},
+			expectedVpcId: "vpc-1",
+		},
+		{
+			name:          "falls back to another VPC",
+			vpc:           avov1alpha2.Vpc{Ids: []string{"vpc-1", "vpc-2", "vpc-3"}},
+			fullVpcIds:    []string{"vpc-2"},
+			expectedVpcId: "vpc-3",
+			expectRequeue: true,
+			expectEvent:   true,
+		},
+		{
+			name:          "all VPCs full",
+			vpc:           avov1alpha2.Vpc{Ids: []string{"vpc-1", "vpc-2"}},
+			fullVpcIds:    []string{"vpc-2"},
+			expectedVpcId: "vpc-1",
+			expectRequeue: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mock",
+					Namespace: "mock",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Vpc: test.vpc,
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCId:           "vpc-1",
+					SecurityGroupId: aws_client.MockSecurityGroupId,
+				},
+			}
+
+			recorder := record.NewFakeRecorder(1)
+			r := &VpcEndpointReconciler{
+				Client:            testutil.NewTestMock(t, resource).Client,
+				Recorder:          recorder,
+				log:               testr.New(t),
+				awsClient:         aws_client.NewMockedAwsClientWithSubnets(),
+				vpcQuotaCooldowns: new(vpcQuotaCooldowns),
+			}
+			for _, vpcId := range test.fullVpcIds {
+				r.vpcQuotaCooldowns.markFull(vpcId, time.Now().Add(time.Hour))
+			}
+
+			err := r.fallBackFromFullVpc(context.TODO(), resource, &smithy.GenericAPIError{Code: "VpcEndpointLimitExceeded"})
+			if test.expectRequeue {
+				var requeue *requeueAfterError
+				assert.ErrorAs(t, err, &requeue)
+			} else {
+				assert.True(t, aws_client.IsVpcEndpointLimitExceeded(err))
+			}
+
+			assert.Equal(t, test.expectedVpcId, resource.Status.VPCId)
+			assert.Equal(t, test.expectEvent, len(recorder.Events) == 1)
+			if test.expectEvent {
+				assert.Empty(t, resource.Status.SecurityGroupId)
+				assert.NotEmpty(t, resource.Status.PlacementReason)
+			} else {
+				assert.Equal(t, aws_client.MockSecurityGroupId, resource.Status.SecurityGroupId)
+			}
+		})
+	}
+}
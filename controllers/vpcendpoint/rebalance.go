@@ -0,0 +1,186 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// vpcMoveTracker records when VPC Endpoints were moved away from each set of load balanced VPCs to limit how many are
+// moved per .spec.vpc.rebalance.interval. It is only kept in memory, so the limit starts over when AVO restarts.
+type vpcMoveTracker struct {
+	mu    sync.Mutex
+	moves map[string][]time.Time
+}
+
+// tryMove records a move for the set of VPCs identified by key at now and returns true, unless maxMoves moves have
+// already been recorded for it within the preceding interval
+func (t *vpcMoveTracker) tryMove(key string, now time.Time, interval time.Duration, maxMoves int32) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.moves == nil {
+		t.moves = map[string][]time.Time{}
+	}
+
+	recent := slices.DeleteFunc(t.moves[key], func(move time.Time) bool {
+		return !move.After(now.Add(-interval))
+	})
+	if len(recent) >= int(maxMoves) {
+		t.moves[key] = recent
+		return false
+	}
+
+	t.moves[key] = append(recent, now)
+	return true
+}
+
+// inMaintenanceWindow returns true if now is within the daily window, or if no window is specified
+func inMaintenanceWindow(window *avov1alpha2.MaintenanceWindow, now time.Time) (bool, error) {
+	if window == nil {
+		return true, nil
+	}
+
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return false, fmt.Errorf("invalid maintenance window start %s: %w", window.Start, err)
+	}
+
+	now = now.UTC()
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+	// A window that started yesterday may last past midnight
+	for _, windowStart := range []time.Time{todayStart, todayStart.AddDate(0, 0, -1)} {
+		if !now.Before(windowStart) && now.Before(windowStart.Add(window.Duration.Duration)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// rebalanceTarget returns the least-used VPC in vpcePerVpc if it has at least skewThreshold fewer VPC Endpoints than
+// the current VPC, ties are broken by VPC id so that the same VPC is chosen every time
+func rebalanceTarget(current string, vpcePerVpc map[string]int, skewThreshold int32) (string, bool) {
+	target := ""
+	for vpcId, vpceCount := range vpcePerVpc {
+		if target == "" || vpceCount < vpcePerVpc[target] || (vpceCount == vpcePerVpc[target] && vpcId < target) {
+			target = vpcId
+		}
+	}
+
+	if target == "" || target == current || vpcePerVpc[current]-vpcePerVpc[target] < int(skewThreshold) {
+		return "", false
+	}
+
+	return target, true
+}
+
+// validateVpcRebalance moves an available VPC Endpoint to the least-used VPC it's load balanced across according to
+// .spec.vpc.rebalance, using the same flow as migrating to another VPC
+func (r *VpcEndpointReconciler) validateVpcRebalance(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	if resource == nil {
+		// Should never happen
+		return errors.New("resource must be specified")
+	}
+
+	rebalance := resource.Spec.Vpc.Rebalance
+	if rebalance == nil || !rebalance.Enabled {
+		return nil
+	}
+
+	if resource.Status.Migration != nil || resource.Status.Status != "available" {
+		// Only move VPC Endpoints that are serving traffic and aren't already being moved
+		return nil
+	}
+
+	ok, err := inMaintenanceWindow(rebalance.MaintenanceWindow, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		r.log.V(1).Info("Outside of the maintenance window, not rebalancing")
+		return nil
+	}
+
+	vpcIds, err := r.candidateVpcIds(ctx, resource)
+	if err != nil {
+		return err
+	}
+	// Never move to a VPC that recently reached its VPC Endpoint quota, as placeVpcEndpoint does
+	available, _ := r.vpcQuotaCooldowns.available(vpcIds, time.Now())
+	targets := slices.DeleteFunc(slices.Clone(vpcIds), func(vpcId string) bool {
+		return vpcId != resource.Status.VPCId && !slices.Contains(available, vpcId)
+	})
+	if len(targets) < 2 {
+		return nil
+	}
+
+	vpcePerVpc, err := r.awsClient.CountVPCEndpointsPerVPC(ctx, targets...)
+	if err != nil {
+		return err
+	}
+
+	var (
+		skewThreshold int32 = defaultRebalanceSkewThreshold
+		maxMoves      int32 = defaultRebalanceMaxMovesPerInterval
+		interval            = defaultRebalanceInterval
+	)
+	if rebalance.SkewThreshold > 0 {
+		skewThreshold = rebalance.SkewThreshold
+	}
+	if rebalance.MaxMovesPerInterval > 0 {
+		maxMoves = rebalance.MaxMovesPerInterval
+	}
+	if rebalance.Interval.Duration > 0 {
+		interval = rebalance.Interval.Duration
+	}
+
+	target, ok := rebalanceTarget(resource.Status.VPCId, vpcePerVpc, skewThreshold)
+	if !ok {
+		return nil
+	}
+
+	// The moves are limited per set of load balanced VPCs
+	pool := slices.Clone(vpcIds)
+	slices.Sort(pool)
+	if !r.vpcMoves.tryMove(strings.Join(pool, ","), time.Now(), interval, maxMoves) {
+		r.log.V(0).Info("Not rebalancing VPC Endpoint, maximum moves per interval reached", "from", resource.Status.VPCId, "to", target)
+		return nil
+	}
+
+	r.log.V(0).Info("Rebalancing VPC Endpoint", "from", resource.Status.VPCId, "to", target, "vpcePerVpc", vpcePerVpc)
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Rebalancing", "Moving VPC Endpoint from %s (%d VPC Endpoints) to %s (%d VPC Endpoints)",
+		resource.Status.VPCId, vpcePerVpc[resource.Status.VPCId], target, vpcePerVpc[target])
+	startVpcMigration(resource, target, "Rebalancing")
+	resource.Status.PlacementReason = fmt.Sprintf("Rebalanced from %s with %d VPC Endpoints to %s with %d VPC Endpoints",
+		resource.Status.Migration.VPCId, vpcePerVpc[resource.Status.Migration.VPCId], target, vpcePerVpc[target])
+	if err := r.Status().Update(ctx, resource); err != nil {
+		r.log.V(0).Error(err, "failed to update status")
+		return err
+	}
+
+	return &requeueAfterError{after: migrationRequeueDelay, reason: "rebalancing VPC Endpoint"}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"testing"
+	"time"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVpcMoveTracker_tryMove(t *testing.T) {
+	tracker := new(vpcMoveTracker)
+	now := time.Now()
+
+	assert.True(t, tracker.tryMove("vpc-1,vpc-2", now, time.Hour, 2))
+	assert.True(t, tracker.tryMove("vpc-1,vpc-2", now.Add(time.Minute), time.Hour, 2))
+	assert.False(t, tracker.tryMove("vpc-1,vpc-2", now.Add(time.Minute*2), time.Hour, 2))
+	// Other sets of VPCs are limited separately
+	assert.True(t, tracker.tryMove("vpc-3,vpc-4", now.Add(time.Minute*2), time.Hour, 2))
+	// The first move falls out of the interval
+	assert.True(t, tracker.tryMove("vpc-1,vpc-2", now.Add(time.Hour), time.Hour, 2))
+	assert.False(t, tracker.tryMove("vpc-1,vpc-2", now.Add(time.Hour), time.Hour, 2))
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		window    *avov1alpha2.MaintenanceWindow
+		now       time.Time
+		expected  bool
+		expectErr bool
+	}{
+		{
+			name:     "no window",
+			now:      time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "within window",
+			window:   &avov1alpha2.MaintenanceWindow{Start: "11:00", Duration: metav1.Duration{Duration: time.Hour * 2}},
+			now:      time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "before window",
+			window:   &avov1alpha2.MaintenanceWindow{Start: "13:00", Duration: metav1.Duration{Duration: time.Hour}},
+			now:      time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "after window",
+			window:   &avov1alpha2.MaintenanceWindow{Start: "10:00", Duration: metav1.Duration{Duration: time.Hour}},
+			now:      time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "window past midnight",
+			window:   &avov1alpha2.MaintenanceWindow{Start: "23:00", Duration: metav1.Duration{Duration: time.Hour * 3}},
+			now:      time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "converted to UTC",
+			window:   &avov1alpha2.MaintenanceWindow{Start: "12:00", Duration: metav1.Duration{Duration: time.Hour}},
+			now:      time.Date(2024, 1, 1, 7, 30, 0, 0, time.FixedZone("EST", -5*60*60)),
+			expected: true,
+		},
+		{
+			name:      "invalid start",
+			window:    &avov1alpha2.MaintenanceWindow{Start: "noon", Duration: metav1.Duration{Duration: time.Hour}},
+			now:       time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := inMaintenanceWindow(test.window, test.now)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRebalanceTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    string
+		vpcePerVpc map[string]int
+		threshold  int32
+		expected   string
+		expectMove bool
+	}{
+		{
+			name:       "skewed",
+			current:    "vpc-1",
+			vpcePerVpc: map[string]int{"vpc-1": 10, "vpc-2": 8, "vpc-3": 0},
+			threshold:  2,
+			expected:   "vpc-3",
+			expectMove: true,
+		},
+		{
+			name:       "below threshold",
+			current:    "vpc-1",
+			vpcePerVpc: map[string]int{"vpc-1": 10, "vpc-2": 9},
+			threshold:  2,
+		},
+		{
+			name:       "already least used",
+			current:    "vpc-2",
+			vpcePerVpc: map[string]int{"vpc-1": 10, "vpc-2": 0},
+			threshold:  2,
+		},
+		{
+			name:       "ties broken by id",
+			current:    "vpc-1",
+			vpcePerVpc: map[string]int{"vpc-1": 10, "vpc-3": 0, "vpc-2": 0},
+			threshold:  2,
+			expected:   "vpc-2",
+			expectMove: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, ok := rebalanceTarget(test.current, test.vpcePerVpc, test.threshold)
+			assert.Equal(t, test.expectMove, ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
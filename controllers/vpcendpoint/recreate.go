@@ -0,0 +1,161 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// vpcEndpointNeedsRecreation returns true if .spec.recreatePolicy requires a VPC Endpoint in the given state to be
+// deleted and recreated
+func vpcEndpointNeedsRecreation(resource *avov1alpha2.VpcEndpoint, state ec2Types.State) bool {
+	switch resource.Spec.RecreatePolicy {
+	case recreatePolicyNever:
+		return false
+	case recreatePolicyOnFailed:
+		return state == "rejected" || state == "failed"
+	default:
+		// OnRejected
+		return state == "rejected"
+	}
+}
+
+// recreateBackoff returns the maximum number of re-creations and the initial and maximum delay between them
+// specified by .spec.recreateBackoff, falling back on defaults for unspecified values
+func recreateBackoff(resource *avov1alpha2.VpcEndpoint) (int32, time.Duration, time.Duration) {
+	var (
+		maxAttempts  int32 = defaultRecreateMaxAttempts
+		initialDelay       = defaultRecreateInitialDelay
+		maxDelay           = defaultRecreateMaxDelay
+	)
+
+	if backoff := resource.Spec.RecreateBackoff; backoff != nil {
+		if backoff.MaxAttempts > 0 {
+			maxAttempts = backoff.MaxAttempts
+		}
+		if backoff.InitialDelay.Duration > 0 {
+			initialDelay = backoff.InitialDelay.Duration
+		}
+		if backoff.MaxDelay.Duration > 0 {
+			maxDelay = backoff.MaxDelay.Duration
+		}
+	}
+
+	return maxAttempts, initialDelay, maxDelay
+}
+
+// recreateDelay returns the delay between the previous re-creation and the next one given the number of attempts so
+// far: none for the first re-creation, then initialDelay doubling for every following attempt, up to maxDelay
+func recreateDelay(attempts int32, initialDelay, maxDelay time.Duration) time.Duration {
+	if attempts == 0 {
+		return 0
+	}
+
+	delay := initialDelay
+	for i := int32(1); i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
+
+// recreateVpcEndpoint deletes a rejected or failed VPC Endpoint so that a new one is created, waiting between
+// re-creations and giving up after the maximum number of attempts according to .spec.recreateBackoff.
+// Each re-creation is recorded in the resource's status and emits an Event.
+func (r *VpcEndpointReconciler) recreateVpcEndpoint(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	vpcePendingAcceptance.WithLabelValues(resource.Name, resource.Namespace, resource.Status.VPCEndpointId).Set(0)
+	maxAttempts, initialDelay, maxDelay := recreateBackoff(resource)
+	reason := fmt.Sprintf("VPC Endpoint %s was %s", *vpce.VpcEndpointId, vpce.State)
+
+	if resource.Status.RecreateAttempts >= maxAttempts {
+		// Retrying won't help until the VPC Endpoint Service is fixed and .spec.recreateBackoff.maxAttempts is raised
+		r.log.V(0).Info("Not recreating VPC Endpoint, maximum attempts reached", "id", *vpce.VpcEndpointId, "attempts", resource.Status.RecreateAttempts)
+		resource.Status.LastFailureReason = fmt.Sprintf("%s and was not recreated after %d attempts", reason, resource.Status.RecreateAttempts)
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSVpcEndpointCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "RecreateAttemptsExhausted",
+			Message: resource.Status.LastFailureReason,
+		})
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+
+		return nil
+	}
+
+	if resource.Status.LastRecreateTime != nil {
+		next := resource.Status.LastRecreateTime.Add(recreateDelay(resource.Status.RecreateAttempts, initialDelay, maxDelay))
+		if wait := time.Until(next); wait > 0 {
+			r.log.V(0).Info("Waiting to recreate VPC Endpoint", "id", *vpce.VpcEndpointId, "after", wait.String())
+			meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+				Type:    avov1alpha2.AWSVpcEndpointCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  string(vpce.State),
+				Message: fmt.Sprintf("%s, recreating it at %s", reason, next.UTC().Format(time.RFC3339)),
+			})
+			if err := r.Status().Update(ctx, resource); err != nil {
+				r.log.V(0).Error(err, "failed to update status")
+				return err
+			}
+
+			return &requeueAfterError{after: wait, reason: reason}
+		}
+	}
+
+	r.log.V(0).Info("Recreating VPC Endpoint", "id", *vpce.VpcEndpointId, "status", string(vpce.State))
+	if _, err := r.awsClient.DeleteVPCEndpoint(ctx, *vpce.VpcEndpointId); err != nil {
+		var ae smithy.APIError
+		if !errors.As(err, &ae) || ae.ErrorCode() != "InvalidVpcEndpoint.NotFound" {
+			return err
+		}
+	}
+
+	now := metav1.Now()
+	resource.Status.RecreateAttempts++
+	resource.Status.LastRecreateTime = &now
+	resource.Status.LastFailureReason = reason
+	resource.Status.VPCEndpointId = ""
+	resource.Status.NetworkInterfaceIds = nil
+	resource.Status.ReplacedNetworkInterfaceIds = nil
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Recreating", "Deleted VPC endpoint %s to recreate it after it was %s (attempt %d/%d)",
+		*vpce.VpcEndpointId, vpce.State, resource.Status.RecreateAttempts, maxAttempts)
+
+	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+		Type:    avov1alpha2.AWSVpcEndpointCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "Recreating",
+		Message: reason,
+	})
+	if err := r.Status().Update(ctx, resource); err != nil {
+		r.log.V(0).Error(err, "failed to update status")
+		return err
+	}
+
+	return &requeueAfterError{after: recreateRequeueDelay, reason: "recreating VPC Endpoint"}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr/testr"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestVpcEndpointNeedsRecreation(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		state    ec2Types.State
+		expected bool
+	}{
+		{
+			name:     "default rejected",
+			state:    "rejected",
+			expected: true,
+		},
+		{
+			name:     "default failed",
+			state:    "failed",
+			expected: false,
+		},
+		{
+			name:     "OnFailed failed",
+			policy:   recreatePolicyOnFailed,
+			state:    "failed",
+			expected: true,
+		},
+		{
+			name:     "OnFailed rejected",
+			policy:   recreatePolicyOnFailed,
+			state:    "rejected",
+			expected: true,
+		},
+		{
+			name:     "Never",
+			policy:   recreatePolicyNever,
+			state:    "rejected",
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					RecreatePolicy: test.policy,
+				},
+			}
+
+			assert.Equal(t, test.expected, vpcEndpointNeedsRecreation(resource, test.state))
+		})
+	}
+}
+
+func TestRecreateDelay(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		expected time.Duration
+	}{
+		{
+			attempts: 0,
+			expected: 0,
+		},
+		{
+			attempts: 1,
+			expected: time.Minute,
+		},
+		{
+			attempts: 3,
+			expected: time.Minute * 4,
+		},
+		{
+			attempts: 10,
+			expected: time.Minute * 10,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, recreateDelay(test.attempts, time.Minute, time.Minute*10))
+	}
+}
+
+func TestVpcEndpointReconciler_recreateVpcEndpoint(t *testing.T) {
+	tests := []struct {
+		name             string
+		attempts         int32
+		lastRecreateTime *metav1.Time
+		expectRecreated  bool
+		expectRequeue    bool
+		expectedReason   string
+	}{
+		{
+			name:            "first attempt",
+			expectRecreated: true,
+			expectRequeue:   true,
+			expectedReason:  "Recreating",
+		},
+		{
+			name:             "backing off",
+			attempts:         2,
+			lastRecreateTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			expectRequeue:    true,
+			expectedReason:   "rejected",
+		},
+		{
+			name:             "backed off",
+			attempts:         2,
+			lastRecreateTime: &metav1.Time{Time: time.Now().Add(-time.Minute * 3)},
+			expectRecreated:  true,
+			expectRequeue:    true,
+			expectedReason:   "Recreating",
+		},
+		{
+			name:             "attempts exhausted",
+			attempts:         defaultRecreateMaxAttempts,
+			lastRecreateTime: &metav1.Time{Time: time.Now().Add(-time.Hour * 24)},
+			expectedReason:   "RecreateAttemptsExhausted",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mock",
+					Namespace: "mock",
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId:    testutil.MockVpcEndpointId,
+					RecreateAttempts: test.attempts,
+					LastRecreateTime: test.lastRecreateTime,
+				},
+			}
+
+			recorder := record.NewFakeRecorder(1)
+			r := &VpcEndpointReconciler{
+				Client:    testutil.NewTestMock(t, resource).Client,
+				Recorder:  recorder,
+				log:       testr.New(t),
+				awsClient: aws_client.NewMockedAwsClient(),
+			}
+
+			vpce := &ec2Types.VpcEndpoint{
+				VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+				State:         "rejected",
+			}
+
+			err := r.recreateVpcEndpoint(context.TODO(), vpce, resource)
+			if test.expectRequeue {
+				var requeue *requeueAfterError
+				assert.ErrorAs(t, err, &requeue)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectRecreated, len(recorder.Events) == 1)
+			if test.expectRecreated {
+				assert.Equal(t, test.attempts+1, resource.Status.RecreateAttempts)
+				assert.Empty(t, resource.Status.VPCEndpointId)
+			} else {
+				assert.Equal(t, test.attempts, resource.Status.RecreateAttempts)
+				assert.Equal(t, testutil.MockVpcEndpointId, resource.Status.VPCEndpointId)
+			}
+
+			cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition)
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, test.expectedReason, cond.Reason)
+			}
+		})
+	}
+}
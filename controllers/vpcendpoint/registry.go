@@ -0,0 +1,178 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+)
+
+// ownershipRecordName returns the name of the TXT record that records the owner of the Route53 record named name
+func ownershipRecordName(name string) string {
+	if rest, ok := strings.CutPrefix(name, "*."); ok {
+		return fmt.Sprintf("%s.%s", ownershipWildcardRecordPrefix, rest)
+	}
+
+	return fmt.Sprintf("%s.%s", ownershipRecordPrefix, name)
+}
+
+// ownedRecordName returns the name of the Route53 record whose owner is recorded by the TXT record named name, if name
+// is the name of an ownership TXT record
+func ownedRecordName(name string) (string, bool) {
+	name = aws_client.NormalizeRecordName(name)
+	if rest, ok := strings.CutPrefix(name, ownershipWildcardRecordPrefix+"."); ok {
+		return "*." + rest, true
+	}
+
+	return strings.CutPrefix(name, ownershipRecordPrefix+".")
+}
+
+// generateOwnershipRecord generates the TXT record that records the VpcEndpoint CR as the owner of the Route53 record
+// named name
+func generateOwnershipRecord(resource *avov1alpha2.VpcEndpoint, name string) *route53Types.ResourceRecordSet {
+	value := fmt.Sprintf("\"%s,%s=%s,%s=vpcendpoint/%s/%s\"", ownershipHeritage, ownershipOwnerKey, resource.UID,
+		ownershipResourceKey, resource.Namespace, resource.Name)
+
+	return &route53Types.ResourceRecordSet{
+		Name:            aws.String(ownershipRecordName(name)),
+		Type:            route53Types.RRTypeTxt,
+		TTL:             aws.Int64(defaultRecordTTL),
+		ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(value)}},
+	}
+}
+
+// recordOwner returns the UID of the VpcEndpoint CR recorded as the owner by an ownership TXT record, or an empty string
+// if rrs isn't an ownership TXT record
+func recordOwner(rrs route53Types.ResourceRecordSet) string {
+	if rrs.Type != route53Types.RRTypeTxt {
+		return ""
+	}
+
+	for _, rr := range rrs.ResourceRecords {
+		fields := strings.Split(strings.Trim(aws.ToString(rr.Value), "\""), ",")
+		if len(fields) == 0 || fields[0] != ownershipHeritage {
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			if owner, ok := strings.CutPrefix(field, ownershipOwnerKey+"="); ok {
+				return owner
+			}
+		}
+	}
+
+	return ""
+}
+
+// route53RecordIndex is the Route53 records in a hosted zone indexed by their normalized name, so that a hosted zone
+// is only listed once per reconcile instead of once per record
+type route53RecordIndex map[string][]route53Types.ResourceRecordSet
+
+// listRoute53Records lists all Route53 records in a hosted zone and indexes them by name
+func (r *VpcEndpointReconciler) listRoute53Records(ctx context.Context, hostedZoneId string) (route53RecordIndex, error) {
+	records, err := r.awsClient.ListAllResourceRecordSets(ctx, hostedZoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	index := route53RecordIndex{}
+	for _, rrs := range records {
+		name := aws_client.NormalizeRecordName(aws.ToString(rrs.Name))
+		index[name] = append(index[name], rrs)
+	}
+
+	return index, nil
+}
+
+// named returns the Route53 records named name
+func (index route53RecordIndex) named(name string) []route53Types.ResourceRecordSet {
+	return index[aws_client.NormalizeRecordName(name)]
+}
+
+// owner returns the UID of the VpcEndpoint CR that owns the Route53 record named name and the ownership TXT record
+// recording it, or an empty string and nil if the record has no recorded owner
+func (index route53RecordIndex) owner(name string) (string, *route53Types.ResourceRecordSet) {
+	for _, rrs := range index.named(ownershipRecordName(name)) {
+		if owner := recordOwner(rrs); owner != "" {
+			return owner, &rrs
+		}
+	}
+
+	return "", nil
+}
+
+// isManagedRecordType returns true for the types of Route53 records that AVO creates pointing to VPC Endpoints
+func isManagedRecordType(recordType route53Types.RRType) bool {
+	switch recordType {
+	case route53Types.RRTypeCname, route53Types.RRTypeA, route53Types.RRTypeAaaa:
+		return true
+	default:
+		return false
+	}
+}
+
+// deleteOwnedRoute53Records deletes all Route53 records in .status.hostedZoneId with an ownership TXT record naming the
+// VpcEndpoint CR as the owner. Records created outside of AVO or owned by other VpcEndpoints are left alone and
+// returned, as they prevent the hosted zone from being deleted until they are removed.
+func (r *VpcEndpointReconciler) deleteOwnedRoute53Records(ctx context.Context, resource *avov1alpha2.VpcEndpoint) ([]string, error) {
+	index, err := r.listRoute53Records(ctx, resource.Status.HostedZoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	var foreign []string
+	owned := map[string]bool{}
+	for name := range index {
+		if ownedName, ok := ownedRecordName(name); ok {
+			if owner, _ := index.owner(ownedName); owner == string(resource.UID) {
+				owned[ownedName] = true
+			}
+		}
+	}
+
+	for name, records := range index {
+		for _, rrs := range records {
+			switch {
+			case rrs.Type == route53Types.RRTypeNs || rrs.Type == route53Types.RRTypeSoa:
+				// The default SOA and NS records are deleted along with the hosted zone
+				continue
+			case owned[name] && isManagedRecordType(rrs.Type):
+				continue
+			}
+
+			if ownedName, ok := ownedRecordName(name); !ok || !owned[ownedName] {
+				foreign = append(foreign, fmt.Sprintf("%s (%s)", name, rrs.Type))
+			}
+		}
+	}
+	slices.Sort(foreign)
+
+	for name := range owned {
+		if err := r.deleteRoute53Records(ctx, resource, resource.Status.HostedZoneId, index, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return foreign, nil
+}
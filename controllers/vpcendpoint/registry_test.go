@@ -0,0 +1,131 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/go-logr/logr/testr"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOwnershipRecordName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{
+			name:     "api.example.com",
+			expected: "_avo-owner.api.example.com",
+		},
+		{
+			name:     "*.apps.example.com",
+			expected: "_avo-owner-wildcard.apps.example.com",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ownershipRecordName(test.name))
+
+			// Route 53 returns record names with a trailing "."
+			name, ok := ownedRecordName(test.expected + ".")
+			assert.True(t, ok)
+			assert.Equal(t, test.name, name)
+		})
+	}
+
+	_, ok := ownedRecordName("api.example.com.")
+	assert.False(t, ok)
+}
+
+func TestRecordOwner(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mock",
+			Namespace: "mock",
+			UID:       "1234",
+		},
+	}
+
+	rrs := generateOwnershipRecord(resource, "api.example.com")
+	assert.Equal(t, "_avo-owner.api.example.com", *rrs.Name)
+	assert.Equal(t, "1234", recordOwner(*rrs))
+
+	assert.Empty(t, recordOwner(route53Types.ResourceRecordSet{
+		Type:            route53Types.RRTypeTxt,
+		ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String("\"heritage=external-dns,external-dns/owner=1234\"")}},
+	}))
+	assert.Empty(t, recordOwner(route53Types.ResourceRecordSet{
+		Type:            route53Types.RRTypeCname,
+		ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String("vpce-12345.amazonaws.com")}},
+	}))
+}
+
+func TestVpcEndpointReconciler_listRoute53Records(t *testing.T) {
+	r := &VpcEndpointReconciler{
+		log:       testr.New(t),
+		awsClient: aws_client.NewMockedAwsClient(),
+	}
+
+	index, err := r.listRoute53Records(context.TODO(), aws_client.MockHostedZoneId)
+	assert.NoError(t, err)
+	assert.Len(t, index.named("mock."), 1)
+	assert.Empty(t, index.named("other"))
+
+	// The mocked hosted zone contains a CNAME record without an ownership TXT record
+	owner, ownershipRecord := index.owner("mock")
+	assert.Empty(t, owner)
+	assert.Nil(t, ownershipRecord)
+
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: "1234",
+		},
+	}
+	rrs := generateOwnershipRecord(resource, "mock")
+	index[*rrs.Name] = append(index[*rrs.Name], *rrs)
+	owner, ownershipRecord = index.owner("mock")
+	assert.Equal(t, "1234", owner)
+	assert.Equal(t, rrs, ownershipRecord)
+}
+
+func TestVpcEndpointReconciler_deleteOwnedRoute53Records(t *testing.T) {
+	r := &VpcEndpointReconciler{
+		log:       testr.New(t),
+		awsClient: aws_client.NewMockedAwsClient(),
+	}
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: "1234",
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			HostedZoneId: aws_client.MockHostedZoneId,
+		},
+	}
+
+	// The mocked hosted zone contains a CNAME record without an ownership TXT record
+	foreign, err := r.deleteOwnedRoute53Records(context.TODO(), resource)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mock (CNAME)"}, foreign)
+}
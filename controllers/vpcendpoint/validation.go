@@ -20,12 +20,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
-	"github.com/aws/smithy-go"
 	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
 	"github.com/openshift/aws-vpce-operator/pkg/dnses"
@@ -39,6 +40,17 @@ import (
 
 type Validation func(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error
 
+// requeueAfterError is returned by a Validation to stop reconciling the resource and check it again after a delay,
+// without treating it as a failure
+type requeueAfterError struct {
+	after  time.Duration
+	reason string
+}
+
+func (e *requeueAfterError) Error() string {
+	return fmt.Sprintf("requeue after %s: %s", e.after, e.reason)
+}
+
 func (r *VpcEndpointReconciler) validateResources(ctx context.Context, resource *avov1alpha2.VpcEndpoint, validations []Validation) error {
 	for _, validation := range validations {
 		if err := validation(ctx, resource); err != nil {
@@ -57,6 +69,27 @@ func (r *VpcEndpointReconciler) validateSecurityGroup(ctx context.Context, resou
 		return fmt.Errorf("resource must be specified")
 	}
 
+	if !vpcEndpointSupportsSecurityGroups(resource) {
+		r.log.V(1).Info("Skipping security group, not supported by VPC Endpoint type", "type", vpcEndpointType(resource))
+		return nil
+	}
+
+	if resource.Spec.SecurityGroup.Mode == securityGroupModeUserProvided {
+		r.log.V(1).Info("Skipping security group, only user-provided security groups are attached")
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSSecurityGroupCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "UserProvided",
+			Message: "Using user-provided security groups",
+		})
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+
+		return nil
+	}
+
 	sg, err := r.findOrCreateSecurityGroup(ctx, resource)
 	if err != nil {
 		return err
@@ -77,12 +110,26 @@ func (r *VpcEndpointReconciler) validateSecurityGroup(ctx context.Context, resou
 		return err
 	}
 
-	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-		Type:    avov1alpha2.AWSSecurityGroupCondition,
-		Status:  metav1.ConditionTrue,
-		Reason:  "Validated",
-		Message: "Validated",
-	})
+	clusterSgsNotFound, err := r.revokeUndesiredSecurityGroupRules(ctx, sg, resource)
+	if err != nil {
+		return err
+	}
+
+	if clusterSgsNotFound {
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSSecurityGroupCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "ClusterSecurityGroupsNotFound",
+			Message: "Validated, but not revoking rules that may allow the cluster's security groups since they were not found",
+		})
+	} else {
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSSecurityGroupCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Validated",
+			Message: "Validated",
+		})
+	}
 	if err := r.Status().Update(ctx, resource); err != nil {
 		r.log.V(0).Error(err, "failed to update status")
 		return err
@@ -99,8 +146,25 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 		return fmt.Errorf("resource must be specified")
 	}
 
+	// Needed before creating the VPC Endpoint to know whether private DNS can be enabled
+	if err := r.updatePrivateDnsNameStatus(ctx, resource); err != nil {
+		return fmt.Errorf("failed to describe VPC Endpoint Service: %w", err)
+	}
+
 	vpce, err := r.findOrCreateVpcEndpoint(ctx, resource)
 	if err != nil {
+		if errors.Is(err, errInvalidPolicy) {
+			// Retrying won't help until the policy is fixed, which will trigger another reconcile
+			r.log.V(0).Info("Not creating VPC Endpoint with an invalid policy", "error", err.Error())
+			setPolicyCondition(resource, err)
+			if err := r.Status().Update(ctx, resource); err != nil {
+				r.log.V(0).Error(err, "failed to update status")
+				return err
+			}
+
+			return nil
+		}
+
 		return err
 	}
 
@@ -148,27 +212,17 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 	case "available":
 		vpcePendingAcceptance.WithLabelValues(resource.Name, resource.Namespace, resource.Status.VPCEndpointId).Set(0)
 		r.log.V(0).Info("VPC Endpoint ready", "id", resource.Status.VPCEndpointId)
-	case "rejected":
-		r.log.V(0).Info("VPC Endpoint rejected, starting deletion", "id", resource.Status.VPCEndpointId)
-		if _, err := r.awsClient.DeleteVPCEndpoint(ctx, resource.Status.VPCEndpointId); err != nil {
-			var ae smithy.APIError
-			if errors.As(err, &ae) {
-				if ae.ErrorCode() == "InvalidVpcEndpoint.NotFound" {
-					resource.Status.VPCEndpointId = ""
-				} else {
-					return err
-				}
-			} else {
-				return err
-			}
+		resource.Status.RecreateAttempts = 0
+	case "rejected", "failed":
+		if vpcEndpointNeedsRecreation(resource, vpce.State) {
+			return r.recreateVpcEndpoint(ctx, vpce, resource)
 		}
 
-		return fmt.Errorf("VPC Endpoint unexpectedly needed to be deleted")
+		fallthrough
 	case ec2Types.StateFailed, ec2Types.StateDeleted:
 		// No other known states, but just in case catch with a default
 		fallthrough
 	default:
-		// TODO: If rejected, we may want an option to recreate the VPC Endpoint and try again
 		vpcePendingAcceptance.WithLabelValues(resource.Name, resource.Namespace, resource.Status.VPCEndpointId).Set(0)
 		r.log.V(0).Info("VPC Endpoint in a bad state", "status", string(vpce.State))
 		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
@@ -189,17 +243,53 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 		return fmt.Errorf("failed to reconcile VPC Endpoint subnets: %w", err)
 	}
 
+	degraded, err := r.validateAZCoverage(ctx, vpce, resource)
+	if err != nil {
+		return fmt.Errorf("failed to validate VPC Endpoint Availability Zone coverage: %w", err)
+	}
+
 	err = r.ensureVpcEndpointSecurityGroups(ctx, vpce, resource)
 	if err != nil {
 		return fmt.Errorf("failed to reconcile VPC Endpoint security groups: %w", err)
 	}
 
-	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-		Type:    avov1alpha2.AWSVpcEndpointCondition,
-		Status:  metav1.ConditionTrue,
-		Reason:  string(vpce.State),
-		Message: fmt.Sprintf("VPC Endpoint status is: %s", string(vpce.State)),
-	})
+	err = r.ensureVpcEndpointRouteTables(ctx, vpce, resource)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile VPC Endpoint route tables: %w", err)
+	}
+
+	err = r.ensureVpcEndpointIpAddressType(ctx, vpce, resource)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile VPC Endpoint IP address type: %w", err)
+	}
+
+	err = r.ensureVpcEndpointPrivateDns(ctx, vpce, resource)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile VPC Endpoint private DNS: %w", err)
+	}
+	setPrivateDnsCondition(resource)
+
+	err = r.ensureVpcEndpointPolicy(ctx, vpce, resource)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile VPC Endpoint policy: %w", err)
+	}
+
+	if degraded != "" {
+		// Never report a VPC Endpoint that doesn't cover enough Availability Zones as ready
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSVpcEndpointCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Degraded",
+			Message: degraded,
+		})
+	} else {
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSVpcEndpointCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  string(vpce.State),
+			Message: fmt.Sprintf("VPC Endpoint status is: %s", string(vpce.State)),
+		})
+	}
 	if err := r.Status().Update(ctx, resource); err != nil {
 		r.log.V(0).Error(err, "failed to update status")
 		return err
@@ -208,7 +298,52 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 	return nil
 }
 
+// setPrivateDnsCondition reflects whether private DNS could be enabled as requested by .spec.enablePrivateDns in
+// the resource's status conditions. The status is not persisted, that is left to the caller.
+func setPrivateDnsCondition(resource *avov1alpha2.VpcEndpoint) {
+	switch {
+	case !resource.Spec.EnablePrivateDns || vpcEndpointType(resource) != ec2Types.VpcEndpointTypeInterface:
+		meta.RemoveStatusCondition(&resource.Status.Conditions, avov1alpha2.AWSPrivateDnsCondition)
+	case resource.Status.PrivateDnsName == "":
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSPrivateDnsCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "NoPrivateDnsName",
+			Message: fmt.Sprintf("VPC Endpoint Service %s does not have a private DNS name", resource.Status.VPCEndpointServiceName),
+		})
+	case !privateDnsNameVerified(resource):
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSPrivateDnsCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "PrivateDnsNameNotVerified",
+			Message: fmt.Sprintf("Private DNS name %s verification state is: %s", resource.Status.PrivateDnsName, resource.Status.PrivateDnsNameVerificationState),
+		})
+	default:
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSPrivateDnsCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Enabled",
+			Message: fmt.Sprintf("Private DNS name %s is enabled", resource.Status.PrivateDnsName),
+		})
+	}
+}
+
 func (r *VpcEndpointReconciler) validateCustomDns(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	if resource != nil && vpcEndpointType(resource) != ec2Types.VpcEndpointTypeInterface {
+		// Gateway and Gateway Load Balancer VPC Endpoints don't have DNS entries to point custom DNS records to
+		return nil
+	}
+
+	if resource != nil && resource.Status.VPCEndpointId == "" {
+		// The VPC Endpoint has not been created yet, e.g. because its policy is invalid
+		return nil
+	}
+
+	if resource != nil && resource.Status.Migration != nil && resource.Status.Status != "available" {
+		// Keep custom DNS pointing to the VPC Endpoint in the previous VPC until the one in the new VPC is available
+		return nil
+	}
+
 	if err := r.validateResources(ctx, resource,
 		[]Validation{
 			r.validateR53PrivateHostedZone,
@@ -348,36 +483,166 @@ func (r *VpcEndpointReconciler) validateR53HostedZoneRecord(ctx context.Context,
 		return err
 	}
 
-	resourceRecord, err := r.generateRoute53Record(ctx, resource)
+	if len(route53Records(resource)) == 0 && len(ownedRoute53RecordNames(resource)) == 0 {
+		return nil
+	}
+
+	// Wait for the last change to propagate before making further changes
+	if resource.Status.Route53ChangeId != "" {
+		synced, err := r.route53ChangeInSync(ctx, resource.Status.Route53ChangeId)
+		if err != nil {
+			return err
+		}
+		if !synced {
+			return &requeueAfterError{after: route53ChangeRequeueDelay, reason: fmt.Sprintf("waiting for Route53 change %s to be INSYNC", resource.Status.Route53ChangeId)}
+		}
+
+		resource.Status.Route53ChangeId = ""
+	}
+
+	inputs, azRecords, err := r.generateRoute53Records(ctx, resource, *resp.HostedZone.Name)
 	if err != nil {
 		r.log.V(0).Info("Skipping Route53 Record", "error", err.Error())
 		return nil
 	}
-
-	input := &route53Types.ResourceRecordSet{
-		Name:            aws.String(fmt.Sprintf("%s.%s", resource.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname, strings.TrimRight(*resp.HostedZone.Name, "."))),
-		ResourceRecords: []route53Types.ResourceRecord{*resourceRecord},
-		TTL:             aws.Int64(300),
-		Type:            route53Types.RRTypeCname,
+	if inputs == nil {
+		// The VPC Endpoint doesn't exist anymore
+		return nil
 	}
 
-	if _, err := r.awsClient.UpsertResourceRecordSet(ctx, input, *resp.HostedZone.Id); err != nil {
+	// List the hosted zone once instead of once per record, Route53 only allows five requests per second per account
+	index, err := r.listRoute53Records(ctx, *resp.HostedZone.Id)
+	if err != nil {
 		return err
 	}
-	r.log.V(0).Info("Route53 Hosted Zone Record exists", "domainName", *input.Name)
 
-	resource.Status.ResourceRecordSet = *input.Name
-	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-		Type:    avov1alpha2.AWSRoute53RecordCondition,
-		Status:  metav1.ConditionTrue,
-		Reason:  "Created",
-		Message: fmt.Sprintf("Created: %s", *input.Name),
-	})
+	names := make([]string, 0, len(inputs))
+	var (
+		conflicts []string
+		stale     []route53Types.ResourceRecordSet
+		upserts   []*route53Types.ResourceRecordSet
+	)
+	for _, input := range inputs {
+		// Refuse to overwrite records owned by another VpcEndpoint, or created outside of AVO
+		owner, _ := index.owner(*input.Name)
+		existing := index.named(*input.Name)
+
+		if owner != "" && owner != string(resource.UID) {
+			r.log.V(0).Info("Route53 Hosted Zone Record is owned by another VpcEndpoint", "domainName", *input.Name, "owner", owner)
+			conflicts = append(conflicts, *input.Name)
+			continue
+		}
+
+		// Records created before ownership TXT records were introduced are tracked in status
+		if owner == "" && !slices.Contains(ownedRoute53RecordNames(resource), *input.Name) &&
+			slices.ContainsFunc(existing, func(rrs route53Types.ResourceRecordSet) bool { return isManagedRecordType(rrs.Type) }) {
+			r.log.V(0).Info("Route53 Hosted Zone Record exists without an owner", "domainName", *input.Name)
+			conflicts = append(conflicts, *input.Name)
+			continue
+		}
+
+		names = append(names, *input.Name)
+		if owner != "" && route53RecordUpToDate(existing, input) {
+			r.log.V(1).Info("Route53 Hosted Zone Record is up to date", "domainName", *input.Name, "type", input.Type)
+			continue
+		}
+
+		// Changing the type of the record, e.g. from CNAME to an alias A record, requires deleting the old record in the
+		// same change batch since a CNAME record can't coexist with other records of the same name
+		for _, rrs := range staleRoute53Records(existing, input) {
+			r.log.V(0).Info("Replacing Route53 Hosted Zone Record", "domainName", *input.Name, "oldType", rrs.Type, "newType", input.Type)
+			stale = append(stale, rrs)
+		}
+		upserts = append(upserts, input, generateOwnershipRecord(resource, *input.Name))
+	}
+
+	if len(upserts) > 0 {
+		changeResp, err := r.awsClient.ReplaceResourceRecordSets(ctx, *resp.HostedZone.Id, stale, upserts...)
+		if err != nil {
+			return err
+		}
+		r.log.V(0).Info("Changed Route53 Hosted Zone Records", "changeId", aws.ToString(changeResp.ChangeInfo.Id), "status", changeResp.ChangeInfo.Status)
+
+		if changeResp.ChangeInfo.Status != route53Types.ChangeStatusInsync {
+			resource.Status.Route53ChangeId = aws.ToString(changeResp.ChangeInfo.Id)
+		}
+	}
+
+	// Delete records that have been removed from the spec
+	for _, name := range ownedRoute53RecordNames(resource) {
+		if !slices.Contains(names, name) && !slices.Contains(conflicts, name) {
+			if err := r.deleteRoute53Records(ctx, resource, *resp.HostedZone.Id, index, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	resource.Status.ResourceRecordSet = ""
+	recordName := route53RecordName(resource.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname, *resp.HostedZone.Name)
+	if resource.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname != "" && slices.Contains(names, recordName) {
+		resource.Status.ResourceRecordSet = recordName
+	}
+	resource.Status.ResourceRecordSets = names
+	for az, azNames := range azRecords {
+		azRecords[az] = slices.DeleteFunc(azNames, func(name string) bool { return slices.Contains(conflicts, name) })
+	}
+	resource.Status.AvailabilityZoneRecords = azRecords
+
+	if len(conflicts) > 0 {
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSRoute53RecordOwnershipCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Conflict",
+			Message: fmt.Sprintf("Records owned by another VpcEndpoint or created outside of AVO: %s", strings.Join(conflicts, ", ")),
+		})
+	} else {
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSRoute53RecordOwnershipCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Owned",
+			Message: "All records are owned by this VpcEndpoint",
+		})
+	}
+
+	switch {
+	case resource.Status.Route53ChangeId != "":
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSRoute53RecordCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Pending",
+			Message: fmt.Sprintf("Waiting for Route53 change %s to propagate: %s", resource.Status.Route53ChangeId, strings.Join(names, ", ")),
+		})
+	case len(names) == 0 && len(conflicts) > 0:
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSRoute53RecordCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Conflict",
+			Message: "All records are owned by another VpcEndpoint or created outside of AVO",
+		})
+	case len(names) == 0:
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSRoute53RecordCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Deleted",
+			Message: "Deleted Route53 Hosted Zone Record",
+		})
+	default:
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:    avov1alpha2.AWSRoute53RecordCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Created",
+			Message: fmt.Sprintf("Created: %s", strings.Join(names, ", ")),
+		})
+	}
 	if err := r.Status().Update(ctx, resource); err != nil {
 		r.log.V(0).Error(err, "failed to update status")
 		return err
 	}
 
+	if resource.Status.Route53ChangeId != "" {
+		return &requeueAfterError{after: route53ChangeRequeueDelay, reason: fmt.Sprintf("waiting for Route53 change %s to be INSYNC", resource.Status.Route53ChangeId)}
+	}
+
 	return nil
 }
 
@@ -108,9 +108,10 @@ func TestVPCEndpointReconciler_validateSecurityGroup(t *testing.T) {
 
 func TestVPCEndpointReconciler_validateVPCEndpoint(t *testing.T) {
 	tests := []struct {
-		name      string
-		resource  *avov1alpha2.VpcEndpoint
-		expectErr bool
+		name             string
+		resource         *avov1alpha2.VpcEndpoint
+		expectPrivateDns bool
+		expectErr        bool
 	}{
 		{
 			name:      "Nil resource",
@@ -129,6 +130,23 @@ func TestVPCEndpointReconciler_validateVPCEndpoint(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "private DNS",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock1",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					EnablePrivateDns: true,
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId:          testutil.MockVpcEndpointId,
+					VPCEndpointServiceName: aws_client.MockVpcEndpointServiceName,
+				},
+			},
+			expectPrivateDns: true,
+			expectErr:        false,
+		},
 	}
 
 	for _, test := range tests {
@@ -139,6 +157,7 @@ func TestVPCEndpointReconciler_validateVPCEndpoint(t *testing.T) {
 		r := &VpcEndpointReconciler{
 			Client:    client,
 			Scheme:    client.Scheme(),
+			Recorder:  record.NewFakeRecorder(1),
 			awsClient: aws_client.NewMockedAwsClientWithSubnets(),
 			log:       testr.New(t),
 			clusterInfo: &clusterInfo{
@@ -156,6 +175,15 @@ func TestVPCEndpointReconciler_validateVPCEndpoint(t *testing.T) {
 				condition := meta.FindStatusCondition(test.resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition)
 				assert.NotNilf(t, condition, "missing expected %s condition", avov1alpha2.AWSVpcEndpointCondition)
 				assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+				privateDnsCondition := meta.FindStatusCondition(test.resource.Status.Conditions, avov1alpha2.AWSPrivateDnsCondition)
+				if test.expectPrivateDns {
+					assert.NotNilf(t, privateDnsCondition, "missing expected %s condition", avov1alpha2.AWSPrivateDnsCondition)
+					assert.Equal(t, metav1.ConditionTrue, privateDnsCondition.Status)
+					assert.Equal(t, aws_client.MockPrivateDnsName, test.resource.Status.PrivateDnsName)
+				} else {
+					assert.Nil(t, privateDnsCondition)
+				}
 			}
 		})
 	}
@@ -209,6 +237,51 @@ func TestVPCEndpointReconciler_validateVPCEndpoint(t *testing.T) {
 //	}
 //}
 
+func TestVPCEndpointReconciler_validateR53HostedZoneRecord_pendingChange(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock1",
+			UID:  "1234",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "test"},
+				},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			HostedZoneId:  aws_client.MockHostedZoneId,
+			VPCEndpointId: testutil.MockVpcEndpointId,
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource).Client
+	r := &VpcEndpointReconciler{
+		Client:    client,
+		Scheme:    client.Scheme(),
+		awsClient: aws_client.NewMockedAwsClient(),
+		log:       testr.New(t),
+	}
+
+	// The mocked change is PENDING until polled with GetChange
+	err := r.validateR53HostedZoneRecord(context.TODO(), resource)
+	var requeue *requeueAfterError
+	assert.ErrorAs(t, err, &requeue)
+	assert.Equal(t, aws_client.MockChangeId, resource.Status.Route53ChangeId)
+	assert.Equal(t, []string{"test." + testutil.MockDomainName}, resource.Status.ResourceRecordSets)
+
+	condition := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "Pending", condition.Reason)
+	}
+
+	synced, err := r.route53ChangeInSync(context.TODO(), resource.Status.Route53ChangeId)
+	assert.NoError(t, err)
+	assert.True(t, synced)
+}
+
 //func TestVPcEndpointReconciler_validateExternalNameService(t *testing.T) {
 //	tests := []struct {
 //		name                    string
@@ -48,6 +48,13 @@ type VpcEndpointReconciler struct {
 	awsClient              *aws_client.AWSClient
 	awsAssociatedVpcClient *aws_client.VpcAssociationClient
 	clusterInfo            *clusterInfo
+
+	// The following are shared by concurrent reconciles, so they are created once in SetupWithManager and guard their
+	// own state with a mutex
+	assumeRoleCache   *assumeRoleCache
+	vpcMoves          *vpcMoveTracker
+	roundRobin        *aws_client.RoundRobinStrategy
+	vpcQuotaCooldowns *vpcQuotaCooldowns
 }
 
 // clusterInfo contains naming and AWS information unique to the cluster
@@ -67,6 +74,7 @@ type clusterInfo struct {
 //+kubebuilder:rbac:groups=v1,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1,resources=services/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=hypershift.openshift.io,resources=awsendpointservices,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *VpcEndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -99,6 +107,12 @@ func (r *VpcEndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if controllerutil.ContainsFinalizer(vpce, avoFinalizer) {
 			// our finalizer is present, so lets handle any external dependency
 			if err := r.cleanupAwsResources(ctx, vpce); err != nil {
+				var requeue *requeueAfterError
+				if errors.As(err, &requeue) {
+					r.log.V(0).Info("Requeueing", "after", requeue.after.String(), "reason", requeue.reason)
+					return ctrl.Result{RequeueAfter: requeue.after}, nil
+				}
+
 				var ae smithy.APIError
 				if errors.As(err, &ae) {
 					// VPC Endpoints take a bit of time to delete, so if there's a dependency error,
@@ -131,7 +145,15 @@ func (r *VpcEndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			r.validateSecurityGroup,
 			r.validateVPCEndpoint,
 			r.validateCustomDns,
+			r.validateVpcMigration,
+			r.validateVpcRebalance,
 		}); err != nil {
+		var requeue *requeueAfterError
+		if errors.As(err, &requeue) {
+			r.log.V(0).Info("Requeueing", "after", requeue.after.String(), "reason", requeue.reason)
+			return ctrl.Result{RequeueAfter: requeue.after}, nil
+		}
+
 		awsUnauthorizedOperationMetricHandler(err)
 
 		return ctrl.Result{}, err
@@ -144,6 +166,10 @@ func (r *VpcEndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 // SetupWithManager sets up the controller with the Manager.
 func (r *VpcEndpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.APIReader = mgr.GetAPIReader()
+	r.assumeRoleCache = new(assumeRoleCache)
+	r.vpcMoves = new(vpcMoveTracker)
+	r.roundRobin = new(aws_client.RoundRobinStrategy)
+	r.vpcQuotaCooldowns = new(vpcQuotaCooldowns)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&avov1alpha2.VpcEndpoint{}).
@@ -98,6 +98,10 @@ data "aws_iam_policy_document" "iam_test" {
     actions = [
       # Extract vpc-id by searching for subnets by tag-key
       "ec2:DescribeSubnets",
+      # Match subnets to the VPC Endpoint Service's Availability Zones by zone id
+      "ec2:DescribeAvailabilityZones",
+      # Compare the static IP addresses of the VPC Endpoint's network interfaces with .spec.vpc.subnetConfigurations
+      "ec2:DescribeNetworkInterfaces",
       # Create and manage security group in specific VPC
       "ec2:CreateSecurityGroup",
       "ec2:DeleteSecurityGroup",
@@ -114,7 +118,9 @@ data "aws_iam_policy_document" "iam_test" {
       # Create and manage a Route53 Record
       "route53:ChangeResourceRecordSets",
       "route53:ListHostedZonesByName",
-      "route53:ListResourceRecordSets"
+      "route53:ListResourceRecordSets",
+      # Wait for Route53 Record changes to propagate
+      "route53:GetChange"
     ]
     resources = ["*"]
   }
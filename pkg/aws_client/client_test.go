@@ -9,6 +9,10 @@ import (
 type mockAvoEC2API struct {
 	describeVpcEndpointResp         *ec2.DescribeVpcEndpointsOutput
 	describeVpcEndpointServicesResp *ec2.DescribeVpcEndpointServicesOutput
+	describeSubnetsResp             *ec2.DescribeSubnetsOutput
+	describeAvailabilityZonesResp   *ec2.DescribeAvailabilityZonesOutput
+	describeNetworkInterfacesResp   *ec2.DescribeNetworkInterfacesOutput
+	describeRouteTablesResp         *ec2.DescribeRouteTablesOutput
 }
 
 func (m mockAvoEC2API) AuthorizeSecurityGroupEgress(ctx context.Context, params *ec2.AuthorizeSecurityGroupEgressInput, optFns ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
@@ -41,11 +45,32 @@ func (m mockAvoEC2API) DescribeSecurityGroupRules(ctx context.Context, params *e
 	panic("implement me")
 }
 
-func (m mockAvoEC2API) DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+func (m mockAvoEC2API) RevokeSecurityGroupEgress(ctx context.Context, params *ec2.RevokeSecurityGroupEgressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
+func (m mockAvoEC2API) RevokeSecurityGroupIngress(ctx context.Context, params *ec2.RevokeSecurityGroupIngressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m mockAvoEC2API) DescribeAvailabilityZones(ctx context.Context, params *ec2.DescribeAvailabilityZonesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	return m.describeAvailabilityZonesResp, nil
+}
+
+func (m mockAvoEC2API) DescribeNetworkInterfaces(ctx context.Context, params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return m.describeNetworkInterfacesResp, nil
+}
+
+func (m mockAvoEC2API) DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	return m.describeRouteTablesResp, nil
+}
+
+func (m mockAvoEC2API) DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	return m.describeSubnetsResp, nil
+}
+
 func (m mockAvoEC2API) DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
 	//TODO implement me
 	panic("implement me")
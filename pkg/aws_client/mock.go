@@ -31,8 +31,12 @@ const (
 	MockClusterTag             = "kubernetes.io/cluster/mock-12345"
 	MockClusterNameTag         = "mock-12345-vpce"
 	MockHostedZoneId           = "R53HZ12345"
+	MockChangeId               = "/change/C12345"
+	MockNetworkInterfaceId     = "eni-12345"
 	MockPublicSubnetId         = "subnet-pub12345"
 	MockPrivateSubnetId        = "subnet-priv12345"
+	MockPrivateDnsName         = "mock.example.com"
+	MockRouteTableId           = "rtb-12345"
 	MockSecurityGroupId        = "sg-12345"
 	MockVpcId                  = "vpc-12345"
 	MockVpcEndpointServiceName = "com.amazonaws.vpce.service.mock-12345"
@@ -62,6 +66,15 @@ var mockResourceRecordSet = &route53Types.ResourceRecordSet{
 
 var mockSubnets = []*ec2Types.Subnet{
 	{
+		CidrBlock: aws.String("10.0.0.0/24"),
+		Ipv6CidrBlockAssociationSet: []ec2Types.SubnetIpv6CidrBlockAssociation{
+			{
+				Ipv6CidrBlock: aws.String("2600:1f18::/64"),
+				Ipv6CidrBlockState: &ec2Types.SubnetCidrBlockState{
+					State: ec2Types.SubnetCidrBlockStateCodeAssociated,
+				},
+			},
+		},
 		SubnetId: aws.String(MockPrivateSubnetId),
 		Tags: []ec2Types.Tag{
 			{
@@ -101,6 +114,19 @@ func NewMockedAwsClientWithSubnets() *AWSClient {
 }
 
 func (m *MockedEC2) DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	if len(params.SubnetIds) > 0 {
+		resp := &ec2.DescribeSubnetsOutput{}
+		for _, subnet := range m.Subnets {
+			for _, id := range params.SubnetIds {
+				if *subnet.SubnetId == id {
+					resp.Subnets = append(resp.Subnets, *subnet)
+				}
+			}
+		}
+
+		return resp, nil
+	}
+
 	tagKeys := map[string]bool{}
 	for _, filter := range params.Filters {
 		for _, tagKey := range filter.Values {
@@ -128,6 +154,58 @@ func (m *MockedEC2) DescribeSubnets(ctx context.Context, params *ec2.DescribeSub
 	return &ec2.DescribeSubnetsOutput{}, nil
 }
 
+func (m *MockedEC2) DescribeAvailabilityZones(ctx context.Context, params *ec2.DescribeAvailabilityZonesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	resp := &ec2.DescribeAvailabilityZonesOutput{}
+	for _, name := range params.ZoneNames {
+		// Zone ids are consistent across AWS accounts, while zone names are not
+		resp.AvailabilityZones = append(resp.AvailabilityZones, ec2Types.AvailabilityZone{
+			ZoneId:   aws.String("id-" + name),
+			ZoneName: aws.String(name),
+		})
+	}
+
+	return resp, nil
+}
+
+func (m *MockedEC2) DescribeNetworkInterfaces(ctx context.Context, params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	networkInterfaceIds := params.NetworkInterfaceIds
+	for _, filter := range params.Filters {
+		if aws.ToString(filter.Name) == "network-interface-id" {
+			networkInterfaceIds = append(networkInterfaceIds, filter.Values...)
+		}
+	}
+
+	resp := &ec2.DescribeNetworkInterfacesOutput{}
+	for _, id := range networkInterfaceIds {
+		resp.NetworkInterfaces = append(resp.NetworkInterfaces, ec2Types.NetworkInterface{
+			NetworkInterfaceId: aws.String(id),
+			PrivateIpAddress:   aws.String("10.0.0.10"),
+			SubnetId:           aws.String(MockPrivateSubnetId),
+		})
+	}
+
+	return resp, nil
+}
+
+func (m *MockedEC2) DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	routeTableIds := params.RouteTableIds
+	if len(routeTableIds) == 0 {
+		routeTableIds = []string{MockRouteTableId}
+	}
+
+	routeTables := make([]ec2Types.RouteTable, len(routeTableIds))
+	for i, id := range routeTableIds {
+		routeTables[i] = ec2Types.RouteTable{
+			RouteTableId: aws.String(id),
+			VpcId:        aws.String(MockVpcId),
+		}
+	}
+
+	return &ec2.DescribeRouteTablesOutput{
+		RouteTables: routeTables,
+	}, nil
+}
+
 func (m *MockedEC2) CreateSecurityGroup(ctx context.Context, params *ec2.CreateSecurityGroupInput, optFns ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
 	if len(params.TagSpecifications) > 0 {
 		return &ec2.CreateSecurityGroupOutput{
@@ -250,6 +328,14 @@ func (m *MockedEC2) AuthorizeSecurityGroupEgress(ctx context.Context, params *ec
 	}, nil
 }
 
+func (m *MockedEC2) RevokeSecurityGroupEgress(ctx context.Context, params *ec2.RevokeSecurityGroupEgressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	return &ec2.RevokeSecurityGroupEgressOutput{Return: aws.Bool(true)}, nil
+}
+
+func (m *MockedEC2) RevokeSecurityGroupIngress(ctx context.Context, params *ec2.RevokeSecurityGroupIngressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	return &ec2.RevokeSecurityGroupIngressOutput{Return: aws.Bool(true)}, nil
+}
+
 func (m *MockedEC2) CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
 	// TODO: this is a no-op
 	return &ec2.CreateTagsOutput{}, nil
@@ -258,8 +344,31 @@ func (m *MockedEC2) CreateTags(ctx context.Context, params *ec2.CreateTagsInput,
 func (m *MockedEC2) CreateVpcEndpoint(ctx context.Context, params *ec2.CreateVpcEndpointInput, optFns ...func(*ec2.Options)) (*ec2.CreateVpcEndpointOutput, error) {
 	return &ec2.CreateVpcEndpointOutput{
 		VpcEndpoint: &ec2Types.VpcEndpoint{
-			State:         "available",
-			VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+			NetworkInterfaceIds: []string{MockNetworkInterfaceId},
+			IpAddressType:       params.IpAddressType,
+			PolicyDocument:      params.PolicyDocument,
+			PrivateDnsEnabled:   params.PrivateDnsEnabled,
+			RouteTableIds:       params.RouteTableIds,
+			State:               "available",
+			SubnetIds:           params.SubnetIds,
+			VpcEndpointId:       aws.String(testutil.MockVpcEndpointId),
+			VpcEndpointType:     params.VpcEndpointType,
+		},
+	}, nil
+}
+
+func (m *MockedEC2) DescribeVpcEndpointServices(ctx context.Context, params *ec2.DescribeVpcEndpointServicesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointServicesOutput, error) {
+	if len(params.ServiceNames) == 0 {
+		return &ec2.DescribeVpcEndpointServicesOutput{}, nil
+	}
+
+	return &ec2.DescribeVpcEndpointServicesOutput{
+		ServiceDetails: []ec2Types.ServiceDetail{
+			{
+				ServiceName:                     aws.String(params.ServiceNames[0]),
+				PrivateDnsName:                  aws.String(MockPrivateDnsName),
+				PrivateDnsNameVerificationState: ec2Types.DnsNameStateVerified,
+			},
 		},
 	}, nil
 }
@@ -291,10 +400,16 @@ func (m *MockedEC2) DescribeVpcEndpoints(ctx context.Context, params *ec2.Descri
 					VpcEndpointId: aws.String(params.VpcEndpointIds[0]),
 					DnsEntries: []ec2Types.DnsEntry{
 						{
-							DnsName: aws.String(testutil.MockVpcEndpointDnsName),
+							DnsName:      aws.String(testutil.MockVpcEndpointDnsName),
+							HostedZoneId: aws.String(testutil.MockVpcEndpointHostedZoneId),
+						},
+						{
+							DnsName:      aws.String(testutil.MockVpcEndpointZonalDnsName),
+							HostedZoneId: aws.String(testutil.MockVpcEndpointHostedZoneId),
 						},
 					},
-					State: "available",
+					NetworkInterfaceIds: []string{MockNetworkInterfaceId},
+					State:               "available",
 				},
 			},
 		}, nil
@@ -310,10 +425,12 @@ func (m *MockedEC2) DescribeVpcEndpoints(ctx context.Context, params *ec2.Descri
 							VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
 							DnsEntries: []ec2Types.DnsEntry{
 								{
-									DnsName: aws.String(testutil.MockVpcEndpointDnsName),
+									DnsName:      aws.String(testutil.MockVpcEndpointDnsName),
+									HostedZoneId: aws.String(testutil.MockVpcEndpointHostedZoneId),
 								},
 							},
-							State: "available",
+							NetworkInterfaceIds: []string{MockNetworkInterfaceId},
+							State:               "available",
 							Tags: []ec2Types.Tag{
 								{
 									Key:   aws.String(filter.Values[0]),
@@ -348,6 +465,15 @@ func (m *MockedRoute53) ListHostedZonesByName(ctx context.Context, params *route
 	}, nil
 }
 
+func (m *MockedRoute53) GetHostedZone(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	return &route53.GetHostedZoneOutput{
+		HostedZone: &route53Types.HostedZone{
+			Id:   params.Id,
+			Name: aws.String(testutil.MockDomainName + "."),
+		},
+	}, nil
+}
+
 func (m *MockedRoute53) ListResourceRecordSets(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
 	return &route53.ListResourceRecordSetsOutput{
 		ResourceRecordSets: []route53Types.ResourceRecordSet{*mockResourceRecordSet},
@@ -355,5 +481,19 @@ func (m *MockedRoute53) ListResourceRecordSets(ctx context.Context, params *rout
 }
 
 func (m *MockedRoute53) ChangeResourceRecordSets(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
-	return &route53.ChangeResourceRecordSetsOutput{}, nil
+	return &route53.ChangeResourceRecordSetsOutput{
+		ChangeInfo: &route53Types.ChangeInfo{
+			Id:     aws.String(MockChangeId),
+			Status: route53Types.ChangeStatusPending,
+		},
+	}, nil
+}
+
+func (m *MockedRoute53) GetChange(ctx context.Context, params *route53.GetChangeInput, optFns ...func(*route53.Options)) (*route53.GetChangeOutput, error) {
+	return &route53.GetChangeOutput{
+		ChangeInfo: &route53Types.ChangeInfo{
+			Id:     params.Id,
+			Status: route53Types.ChangeStatusInsync,
+		},
+	}, nil
 }
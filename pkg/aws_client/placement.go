@@ -0,0 +1,240 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
+)
+
+// Values of .spec.vpc.placementStrategy
+const (
+	PlacementStrategyLeastUsed               = "LeastUsed"
+	PlacementStrategyRoundRobin              = "RoundRobin"
+	PlacementStrategyAvailabilityZoneOverlap = "AvailabilityZoneOverlap"
+	PlacementStrategyQuotaHeadroom           = "QuotaHeadroom"
+)
+
+// VPCPlacementRequest describes a VPC Endpoint to place in one of several VPCs
+type VPCPlacementRequest struct {
+	// VpcIds are the candidate VPCs
+	VpcIds []string
+	// ServiceName is the name of the VPC Endpoint Service, used to look up its Availability Zones
+	ServiceName string
+	// SubnetTags additionally filter the private subnets that determine the Availability Zones of each VPC
+	SubnetTags []v1alpha2.Tag
+	// SubnetDiscoveryMode is how the private subnets that determine the Availability Zones of each VPC are found
+	SubnetDiscoveryMode string
+	// MinFreeIpAddresses excludes private subnets with fewer free IP addresses from the Availability Zones of each VPC
+	MinFreeIpAddresses int
+	// Quota is the maximum number of Interface VPC Endpoints per VPC
+	Quota int
+}
+
+// VPCPlacement is the VPC selected by a VPCPlacementStrategy along with a human-readable reason for selecting it
+type VPCPlacement struct {
+	VpcId  string
+	Reason string
+}
+
+// VPCPlacementStrategy selects the VPC to place a VPC Endpoint in
+type VPCPlacementStrategy interface {
+	Place(ctx context.Context, c *AWSClient, req VPCPlacementRequest) (VPCPlacement, error)
+}
+
+// LeastUsedStrategy uses a "least connection" strategy to place a VPC Endpoint in the VPC with the fewest existing
+// Interface VPC Endpoints in it to balance out quota usage. Ties are broken by VPC ID.
+type LeastUsedStrategy struct{}
+
+func (LeastUsedStrategy) Place(ctx context.Context, c *AWSClient, req VPCPlacementRequest) (VPCPlacement, error) {
+	vpcePerVpc, err := c.CountVPCEndpointsPerVPC(ctx, req.VpcIds...)
+	if err != nil {
+		return VPCPlacement{}, err
+	}
+
+	vpcId := leastUsedVpcId(vpcePerVpc)
+	if vpcId == "" {
+		return VPCPlacement{}, errors.New("unexpectedly did not select a VPC for the VPC Endpoint")
+	}
+
+	return VPCPlacement{
+		VpcId:  vpcId,
+		Reason: fmt.Sprintf("%s has the fewest VPC Endpoints (%d) of %d VPCs", vpcId, vpcePerVpc[vpcId], len(vpcePerVpc)),
+	}, nil
+}
+
+// RoundRobinStrategy places VPC Endpoints in each VPC in turn, ordered by VPC ID. The position in each set of VPCs is
+// only kept in memory, so it starts over when AVO restarts.
+type RoundRobinStrategy struct {
+	mu   sync.Mutex
+	next map[string]int
+}
+
+func (s *RoundRobinStrategy) Place(_ context.Context, _ *AWSClient, req VPCPlacementRequest) (VPCPlacement, error) {
+	if len(req.VpcIds) == 0 {
+		return VPCPlacement{}, errors.New("must specify vpc id when placing a VPC Endpoint")
+	}
+
+	ids := slices.Clone(req.VpcIds)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	key := strings.Join(ids, ",")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.next == nil {
+		s.next = map[string]int{}
+	}
+
+	i := s.next[key] % len(ids)
+	s.next[key] = i + 1
+
+	return VPCPlacement{
+		VpcId:  ids[i],
+		Reason: fmt.Sprintf("%s is VPC %d of %d in round-robin order", ids[i], i+1, len(ids)),
+	}, nil
+}
+
+// AvailabilityZoneOverlapStrategy places a VPC Endpoint in the VPC whose private subnets cover the most Availability
+// Zones supported by the VPC Endpoint Service, not counting subnets without enough free IP addresses. Ties are broken
+// by the fewest existing Interface VPC Endpoints.
+type AvailabilityZoneOverlapStrategy struct{}
+
+func (AvailabilityZoneOverlapStrategy) Place(ctx context.Context, c *AWSClient, req VPCPlacementRequest) (VPCPlacement, error) {
+	vpcePerVpc, err := c.CountVPCEndpointsPerVPC(ctx, req.VpcIds...)
+	if err != nil {
+		return VPCPlacement{}, err
+	}
+
+	serviceAZs, err := c.GetVpcEndpointServiceAZIds(ctx, req.ServiceName)
+	if err != nil {
+		return VPCPlacement{}, err
+	}
+
+	// Do not expect private subnets to have the cluster id when load balancing vpc ids
+	subnets, err := c.DiscoverPrivateSubnets(ctx, req.SubnetDiscoveryMode, "", req.VpcIds, req.SubnetTags...)
+	if err != nil {
+		return VPCPlacement{}, err
+	}
+
+	azsPerVpc := map[string]map[string]bool{}
+	for _, subnet := range subnets {
+		if subnet.VpcId == nil || subnet.AvailabilityZoneId == nil || int(aws.ToInt32(subnet.AvailableIpAddressCount)) < req.MinFreeIpAddresses {
+			continue
+		}
+		if _, ok := serviceAZs[*subnet.AvailabilityZoneId]; !ok {
+			continue
+		}
+		if azsPerVpc[*subnet.VpcId] == nil {
+			azsPerVpc[*subnet.VpcId] = map[string]bool{}
+		}
+		azsPerVpc[*subnet.VpcId][*subnet.AvailabilityZoneId] = true
+	}
+
+	overlapPerVpc := make(map[string]int, len(vpcePerVpc))
+	for vpcId := range vpcePerVpc {
+		overlapPerVpc[vpcId] = len(azsPerVpc[vpcId])
+	}
+
+	vpcId := mostOverlappingVpcId(overlapPerVpc, vpcePerVpc)
+	if vpcId == "" {
+		return VPCPlacement{}, errors.New("unexpectedly did not select a VPC for the VPC Endpoint")
+	}
+	if overlapPerVpc[vpcId] == 0 {
+		return VPCPlacement{}, fmt.Errorf("none of the VPCs %v have private subnets in the Availability Zones supported by %s: %v", req.VpcIds, req.ServiceName, serviceAZs)
+	}
+
+	return VPCPlacement{
+		VpcId: vpcId,
+		Reason: fmt.Sprintf("%s has private subnets in %d of %d Availability Zones supported by the VPC Endpoint Service",
+			vpcId, overlapPerVpc[vpcId], len(serviceAZs)),
+	}, nil
+}
+
+// QuotaHeadroomStrategy places a VPC Endpoint in the VPC with the most headroom left against the per-VPC quota of
+// Interface VPC Endpoints and refuses to place it in a VPC that has reached its quota
+type QuotaHeadroomStrategy struct{}
+
+func (QuotaHeadroomStrategy) Place(ctx context.Context, c *AWSClient, req VPCPlacementRequest) (VPCPlacement, error) {
+	if req.Quota <= 0 {
+		return VPCPlacement{}, errors.New("must specify a quota when placing a VPC Endpoint by quota headroom")
+	}
+
+	vpcePerVpc, err := c.CountVPCEndpointsPerVPC(ctx, req.VpcIds...)
+	if err != nil {
+		return VPCPlacement{}, err
+	}
+
+	vpcId := leastUsedVpcId(vpcePerVpc)
+	if vpcId == "" {
+		return VPCPlacement{}, errors.New("unexpectedly did not select a VPC for the VPC Endpoint")
+	}
+
+	headroom := req.Quota - vpcePerVpc[vpcId]
+	if headroom <= 0 {
+		return VPCPlacement{}, fmt.Errorf("all VPCs %v have reached their quota of %d VPC Endpoints", req.VpcIds, req.Quota)
+	}
+
+	return VPCPlacement{
+		VpcId:  vpcId,
+		Reason: fmt.Sprintf("%s has the most headroom, %d of its quota of %d VPC Endpoints", vpcId, headroom, req.Quota),
+	}, nil
+}
+
+// leastUsedVpcId returns the VPC ID with the fewest VPC Endpoints, breaking ties by VPC ID
+func leastUsedVpcId(vpcePerVpc map[string]int) string {
+	minVpcId := ""
+	for vpcId, vpceCount := range vpcePerVpc {
+		if minVpcId == "" || vpceCount < vpcePerVpc[minVpcId] || (vpceCount == vpcePerVpc[minVpcId] && vpcId < minVpcId) {
+			minVpcId = vpcId
+		}
+	}
+
+	return minVpcId
+}
+
+// mostOverlappingVpcId returns the VPC ID covering the most Availability Zones, breaking ties by the fewest VPC
+// Endpoints and then VPC ID
+func mostOverlappingVpcId(overlapPerVpc, vpcePerVpc map[string]int) string {
+	maxVpcId := ""
+	for vpcId, overlap := range overlapPerVpc {
+		switch {
+		case maxVpcId == "":
+			maxVpcId = vpcId
+		case overlap != overlapPerVpc[maxVpcId]:
+			if overlap > overlapPerVpc[maxVpcId] {
+				maxVpcId = vpcId
+			}
+		case vpcePerVpc[vpcId] != vpcePerVpc[maxVpcId]:
+			if vpcePerVpc[vpcId] < vpcePerVpc[maxVpcId] {
+				maxVpcId = vpcId
+			}
+		case vpcId < maxVpcId:
+			maxVpcId = vpcId
+		}
+	}
+
+	return maxVpcId
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVPCPlacementStrategies(t *testing.T) {
+	vpcEndpoints := &ec2.DescribeVpcEndpointsOutput{
+		VpcEndpoints: []types.VpcEndpoint{
+			{VpcId: aws.String("vpc-01")},
+			{VpcId: aws.String("vpc-01")},
+			{VpcId: aws.String("vpc-01")},
+			{VpcId: aws.String("vpc-02")},
+			{VpcId: aws.String("vpc-02")},
+			{VpcId: aws.String("vpc-03")},
+			{VpcId: aws.String("vpc-03"), VpcEndpointType: types.VpcEndpointTypeGateway},
+			{VpcId: aws.String("vpc-03"), State: "deleting"},
+		},
+	}
+	services := &ec2.DescribeVpcEndpointServicesOutput{
+		ServiceDetails: []types.ServiceDetail{
+			{AvailabilityZones: []string{"us-east-1a", "us-east-1b", "us-east-1c"}},
+		},
+	}
+	zones := &ec2.DescribeAvailabilityZonesOutput{
+		AvailabilityZones: []types.AvailabilityZone{
+			{ZoneId: aws.String("use1-az1"), ZoneName: aws.String("us-east-1a")},
+			{ZoneId: aws.String("use1-az2"), ZoneName: aws.String("us-east-1b")},
+			{ZoneId: aws.String("use1-az3"), ZoneName: aws.String("us-east-1c")},
+		},
+	}
+	subnets := &ec2.DescribeSubnetsOutput{
+		Subnets: []types.Subnet{
+			{VpcId: aws.String("vpc-01"), AvailabilityZone: aws.String("us-east-1a"), AvailabilityZoneId: aws.String("use1-az1"), AvailableIpAddressCount: aws.Int32(100)},
+			{VpcId: aws.String("vpc-02"), AvailabilityZone: aws.String("us-east-1a"), AvailabilityZoneId: aws.String("use1-az1"), AvailableIpAddressCount: aws.Int32(100)},
+			{VpcId: aws.String("vpc-02"), AvailabilityZone: aws.String("us-east-1b"), AvailabilityZoneId: aws.String("use1-az2"), AvailableIpAddressCount: aws.Int32(100)},
+			{VpcId: aws.String("vpc-02"), AvailabilityZone: aws.String("us-east-1d"), AvailabilityZoneId: aws.String("use1-az4"), AvailableIpAddressCount: aws.Int32(100)},
+			{VpcId: aws.String("vpc-03"), AvailabilityZone: aws.String("us-east-1a"), AvailabilityZoneId: aws.String("use1-az1"), AvailableIpAddressCount: aws.Int32(100)},
+			// Full subnets don't count
+			{VpcId: aws.String("vpc-03"), AvailabilityZone: aws.String("us-east-1b"), AvailabilityZoneId: aws.String("use1-az2"), AvailableIpAddressCount: aws.Int32(0)},
+			{VpcId: aws.String("vpc-03"), AvailabilityZone: aws.String("us-east-1c"), AvailabilityZoneId: aws.String("use1-az3"), AvailableIpAddressCount: aws.Int32(0)},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		strategy   VPCPlacementStrategy
+		quota      int
+		expectedId string
+		expectErr  bool
+	}{
+		{
+			name:       "LeastUsed",
+			strategy:   LeastUsedStrategy{},
+			expectedId: "vpc-03",
+		},
+		{
+			name:       "RoundRobin",
+			strategy:   new(RoundRobinStrategy),
+			expectedId: "vpc-01",
+		},
+		{
+			name:       "AvailabilityZoneOverlap",
+			strategy:   AvailabilityZoneOverlapStrategy{},
+			expectedId: "vpc-02",
+		},
+		{
+			name:       "QuotaHeadroom",
+			strategy:   QuotaHeadroomStrategy{},
+			quota:      5,
+			expectedId: "vpc-03",
+		},
+		{
+			name:      "QuotaHeadroom all VPCs full",
+			strategy:  QuotaHeadroomStrategy{},
+			quota:     1,
+			expectErr: true,
+		},
+		{
+			name:      "QuotaHeadroom no quota",
+			strategy:  QuotaHeadroomStrategy{},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &AWSClient{ec2Client: mockAvoEC2API{
+				describeVpcEndpointResp:         vpcEndpoints,
+				describeVpcEndpointServicesResp: services,
+				describeSubnetsResp:             subnets,
+				describeAvailabilityZonesResp:   zones,
+			}}
+
+			placement, err := test.strategy.Place(context.TODO(), client, VPCPlacementRequest{
+				VpcIds:             []string{"vpc-03", "vpc-02", "vpc-01"},
+				ServiceName:        MockVpcEndpointServiceName,
+				Quota:              test.quota,
+				MinFreeIpAddresses: 1,
+			})
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedId, placement.VpcId)
+				assert.NotEmpty(t, placement.Reason)
+			}
+		})
+	}
+}
+
+func TestLeastUsedStrategy_Place(t *testing.T) {
+	tests := []struct {
+		name       string
+		ids        []string
+		resp       *ec2.DescribeVpcEndpointsOutput
+		expectedId string
+		expectErr  bool
+	}{
+		{
+			name:      "No ids",
+			expectErr: true,
+		},
+		{
+			name:       "No VPC Endpoints",
+			ids:        []string{"vpc-01"},
+			resp:       &ec2.DescribeVpcEndpointsOutput{},
+			expectedId: "vpc-01",
+		},
+		{
+			name: "vpc-02 is more empty",
+			ids:  []string{"vpc-01", "vpc-02"},
+			resp: &ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []types.VpcEndpoint{
+					{VpcId: aws.String("vpc-01")},
+					{VpcId: aws.String("vpc-01")},
+					{VpcId: aws.String("vpc-01")},
+					{VpcId: aws.String("vpc-02")},
+				},
+			},
+			expectedId: "vpc-02",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &AWSClient{ec2Client: mockAvoEC2API{describeVpcEndpointResp: test.resp}}
+			placement, err := LeastUsedStrategy{}.Place(context.TODO(), client, VPCPlacementRequest{VpcIds: test.ids})
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedId, placement.VpcId)
+			}
+		})
+	}
+}
+
+func TestRoundRobinStrategy_Place(t *testing.T) {
+	strategy := new(RoundRobinStrategy)
+	req := VPCPlacementRequest{VpcIds: []string{"vpc-02", "vpc-01"}}
+
+	var actual []string
+	for i := 0; i < 3; i++ {
+		placement, err := strategy.Place(context.TODO(), nil, req)
+		assert.NoError(t, err)
+		actual = append(actual, placement.VpcId)
+	}
+
+	assert.Equal(t, []string{"vpc-01", "vpc-02", "vpc-01"}, actual)
+}
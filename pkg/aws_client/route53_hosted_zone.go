@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/openshift/aws-vpce-operator/pkg/util"
+	"strings"
 	"time"
 )
 
@@ -94,6 +95,70 @@ func (c *AWSClient) UpsertResourceRecordSet(ctx context.Context, rrs *types.Reso
 	return c.route53Client.ChangeResourceRecordSets(ctx, input)
 }
 
+// ListAllResourceRecordSets returns all records in a given hosted zone ID across all pages
+func (c *AWSClient) ListAllResourceRecordSets(ctx context.Context, hostedZoneId string) ([]types.ResourceRecordSet, error) {
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneId),
+	}
+
+	var records []types.ResourceRecordSet
+	for {
+		resp, err := c.route53Client.ListResourceRecordSets(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, resp.ResourceRecordSets...)
+
+		if !resp.IsTruncated {
+			return records, nil
+		}
+		input.StartRecordName = resp.NextRecordName
+		input.StartRecordType = resp.NextRecordType
+		input.StartRecordIdentifier = resp.NextRecordIdentifier
+	}
+}
+
+// NormalizeRecordName returns a record name as it is specified, without the trailing "." and octal escape of wildcards
+// that Route 53 returns record names with
+func NormalizeRecordName(name string) string {
+	return strings.ReplaceAll(strings.TrimSuffix(name, "."), `\052`, "*")
+}
+
+// ReplaceResourceRecordSets updates or creates resource record sets and deletes the stale resource record sets in the
+// same change batch, e.g. to change the type of a record in place
+// NOTE: Stale resource record sets must be specified with all the same values that they were created with.
+func (c *AWSClient) ReplaceResourceRecordSets(ctx context.Context, hostedZoneId string, stale []types.ResourceRecordSet, rrs ...*types.ResourceRecordSet) (*route53.ChangeResourceRecordSetsOutput, error) {
+	changes := make([]types.Change, 0, len(stale)+len(rrs))
+	for i := range stale {
+		changes = append(changes, types.Change{
+			Action:            types.ChangeActionDelete,
+			ResourceRecordSet: &stale[i],
+		})
+	}
+	for _, upsert := range rrs {
+		changes = append(changes, types.Change{
+			Action:            types.ChangeActionUpsert,
+			ResourceRecordSet: upsert,
+		})
+	}
+
+	return c.route53Client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		ChangeBatch:  &types.ChangeBatch{Changes: changes},
+		HostedZoneId: aws.String(hostedZoneId),
+	})
+}
+
+// GetChangeStatus returns the status of a change to resource record sets, PENDING until the change has propagated to
+// all Route 53 DNS servers and INSYNC afterward
+func (c *AWSClient) GetChangeStatus(ctx context.Context, changeId string) (types.ChangeStatus, error) {
+	resp, err := c.route53Client.GetChange(ctx, &route53.GetChangeInput{Id: aws.String(changeId)})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.ChangeInfo.Status, nil
+}
+
 // DeleteResourceRecordSet deletes a specific record from a hosted zone
 // NOTE: To delete a resource record set, you must specify all the same values that you specified when you created it.
 func (c *AWSClient) DeleteResourceRecordSet(ctx context.Context, rrs *types.ResourceRecordSet, hostedZoneId string) (*route53.ChangeResourceRecordSetsOutput, error) {
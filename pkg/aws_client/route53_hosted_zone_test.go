@@ -19,8 +19,39 @@ package aws_client
 import (
 	"context"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
 )
 
+// pagedRoute53 returns one record per page of ListResourceRecordSets
+type pagedRoute53 struct {
+	MockedRoute53
+	records []types.ResourceRecordSet
+}
+
+func (m *pagedRoute53) ListResourceRecordSets(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+	i := 0
+	if params.StartRecordName != nil {
+		for i < len(m.records)-1 && (NormalizeRecordName(aws.ToString(m.records[i].Name)) != NormalizeRecordName(aws.ToString(params.StartRecordName)) ||
+			(params.StartRecordType != "" && m.records[i].Type != params.StartRecordType)) {
+			i++
+		}
+	}
+
+	resp := &route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: m.records[i : i+1],
+	}
+	if i+1 < len(m.records) {
+		resp.IsTruncated = true
+		resp.NextRecordName = m.records[i+1].Name
+		resp.NextRecordType = m.records[i+1].Type
+	}
+
+	return resp, nil
+}
+
 func TestAWSClient_ListResourceRecordSets(t *testing.T) {
 	client := NewMockedAwsClient()
 
@@ -29,6 +60,25 @@ func TestAWSClient_ListResourceRecordSets(t *testing.T) {
 	}
 }
 
+func TestAWSClient_ListAllResourceRecordSets(t *testing.T) {
+	client := NewAwsClientWithServiceClients(&MockedEC2{}, &pagedRoute53{
+		records: []types.ResourceRecordSet{
+			{Name: aws.String("a.mock."), Type: types.RRTypeCname},
+			{Name: aws.String("b.mock."), Type: types.RRTypeA},
+			{Name: aws.String("b.mock."), Type: types.RRTypeTxt},
+			{Name: aws.String("c.mock."), Type: types.RRTypeCname},
+		},
+	})
+
+	records, err := client.ListAllResourceRecordSets(context.TODO(), MockHostedZoneId)
+	if err != nil {
+		t.Errorf("expected no err, got %s", err)
+	}
+	if len(records) != 4 {
+		t.Errorf("expected 4 records, got %d", len(records))
+	}
+}
+
 func TestAWSClient_UpsertDeleteResourceRecordSet(t *testing.T) {
 	client := NewMockedAwsClient()
 
@@ -40,3 +90,43 @@ func TestAWSClient_UpsertDeleteResourceRecordSet(t *testing.T) {
 		t.Errorf("expected no err, got %s", err)
 	}
 }
+
+func TestAWSClient_ReplaceResourceRecordSets(t *testing.T) {
+	client := NewMockedAwsClient()
+
+	if _, err := client.ReplaceResourceRecordSets(context.TODO(), MockHostedZoneId, []types.ResourceRecordSet{*mockResourceRecordSet}, mockResourceRecordSet); err != nil {
+		t.Errorf("expected no err, got %s", err)
+	}
+}
+
+func TestNormalizeRecordName(t *testing.T) {
+	tests := map[string]string{
+		"api.example.com.":       "api.example.com",
+		"api.example.com":        "api.example.com",
+		`\052.apps.example.com.`: "*.apps.example.com",
+		"*.apps.example.com":     "*.apps.example.com",
+	}
+
+	for name, expected := range tests {
+		if actual := NormalizeRecordName(name); actual != expected {
+			t.Errorf("expected %s, got %s", expected, actual)
+		}
+	}
+}
+
+func TestAWSClient_GetChangeStatus(t *testing.T) {
+	client := NewMockedAwsClient()
+
+	resp, err := client.ReplaceResourceRecordSets(context.TODO(), MockHostedZoneId, nil, mockResourceRecordSet)
+	if err != nil {
+		t.Errorf("expected no err, got %s", err)
+	}
+
+	status, err := client.GetChangeStatus(context.TODO(), *resp.ChangeInfo.Id)
+	if err != nil {
+		t.Errorf("expected no err, got %s", err)
+	}
+	if status != types.ChangeStatusInsync {
+		t.Errorf("expected %s, got %s", types.ChangeStatusInsync, status)
+	}
+}
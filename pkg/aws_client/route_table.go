@@ -0,0 +1,92 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
+)
+
+// FilterRouteTablesByTags returns the route tables in the provided VPC that have all the provided tags.
+// If no tags are provided, all route tables in the VPC are returned. If no VPC is provided, the route tables with the
+// provided tags in any VPC are returned.
+func (c *AWSClient) FilterRouteTablesByTags(ctx context.Context, vpcId string, tags ...v1alpha2.Tag) ([]types.RouteTable, error) {
+	if vpcId == "" && len(tags) == 0 {
+		return nil, errors.New("must specify vpc id or tags when filtering route tables")
+	}
+
+	filters := generateTagFilters(tags...)
+	if vpcId != "" {
+		filters = append([]types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcId},
+			},
+		}, filters...)
+	}
+
+	input := &ec2.DescribeRouteTablesInput{
+		Filters: filters,
+	}
+
+	var routeTables []types.RouteTable
+	paginator := ec2.NewDescribeRouteTablesPaginator(c.ec2Client, input)
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		routeTables = append(routeTables, resp.RouteTables...)
+	}
+
+	return routeTables, nil
+}
+
+// GetRouteTablesVPCId returns the VPC ID of the provided routeTableIds. Returns an error if the route tables are not
+// in the same VPC.
+func (c *AWSClient) GetRouteTablesVPCId(ctx context.Context, routeTableIds []string) (string, error) {
+	if len(routeTableIds) == 0 {
+		return "", errors.New("no route tables provided")
+	}
+
+	resp, err := c.ec2Client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+		RouteTableIds: routeTableIds,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe route tables: %w", err)
+	}
+
+	if len(resp.RouteTables) == 0 {
+		return "", fmt.Errorf("no route tables found with ids: %v", routeTableIds)
+	}
+
+	vpcId := aws.ToString(resp.RouteTables[0].VpcId)
+	for _, rt := range resp.RouteTables {
+		if aws.ToString(rt.VpcId) != vpcId {
+			return "", fmt.Errorf("route tables %v are a part of multiple VPCs", routeTableIds)
+		}
+	}
+
+	return vpcId, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAWSClient_FilterRouteTablesByTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		vpcId     string
+		tags      []v1alpha2.Tag
+		expectErr bool
+	}{
+		{
+			name:      "vpcId not specified",
+			expectErr: true,
+		},
+		{
+			name:      "all route tables in the VPC",
+			vpcId:     MockVpcId,
+			expectErr: false,
+		},
+		{
+			name: "filtered by tags in any VPC",
+			tags: []v1alpha2.Tag{
+				{
+					Key:   "mock",
+					Value: "",
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name:  "filtered by tags",
+			vpcId: MockVpcId,
+			tags: []v1alpha2.Tag{
+				{
+					Key:   "mock",
+					Value: "",
+				},
+			},
+			expectErr: false,
+		},
+	}
+
+	client := NewMockedAwsClient()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			routeTables, err := client.FilterRouteTablesByTags(context.TODO(), test.vpcId, test.tags...)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, routeTables, 1)
+				assert.Equal(t, MockRouteTableId, aws.ToString(routeTables[0].RouteTableId))
+			}
+		})
+	}
+}
+
+func TestAWSClient_GetRouteTablesVPCId(t *testing.T) {
+	tests := []struct {
+		name          string
+		routeTableIds []string
+		expectErr     bool
+	}{
+		{
+			name:      "no route tables",
+			expectErr: true,
+		},
+		{
+			name:          "route tables in the same VPC",
+			routeTableIds: []string{MockRouteTableId, "rtb-67890"},
+			expectErr:     false,
+		},
+	}
+
+	client := NewMockedAwsClient()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vpcId, err := client.GetRouteTablesVPCId(context.TODO(), test.routeTableIds)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, MockVpcId, vpcId)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/smithy-go"
+	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	"github.com/openshift/aws-vpce-operator/pkg/util"
 )
 
@@ -55,29 +56,74 @@ func (c *AWSClient) FilterClusterNodeSecurityGroupsByDefaultTags(ctx context.Con
 }
 
 // FilterSecurityGroupByDefaultTags describes the security group attached to the VPC Endpoint this operator manages
-// by filtering by the clusterTag and operator tag
-func (c *AWSClient) FilterSecurityGroupByDefaultTags(ctx context.Context, infraName, sgNameTag string) (*ec2.DescribeSecurityGroupsOutput, error) {
+// by filtering by the clusterTag and operator tag, and by VPC if vpcId is specified
+func (c *AWSClient) FilterSecurityGroupByDefaultTags(ctx context.Context, infraName, sgNameTag, vpcId string) (*ec2.DescribeSecurityGroupsOutput, error) {
 	clusterTag, err := util.GetClusterTagKey(infraName)
 	if err != nil {
 		return nil, err
 	}
 
+	filters := []types.Filter{
+		{
+			Name:   aws.String("tag:Name"),
+			Values: []string{sgNameTag},
+		},
+		{
+			Name:   aws.String("tag-key"),
+			Values: []string{clusterTag},
+		},
+		{
+			Name:   aws.String("tag:" + util.OperatorTagKey),
+			Values: []string{util.OperatorTagValue},
+		},
+	}
+
+	// Security group names are only unique within a VPC, e.g. while migrating to another VPC
+	if vpcId != "" {
+		filters = append(filters, types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcId},
+		})
+	}
+
 	return c.ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
-		Filters: []types.Filter{
-			{
-				Name:   aws.String("tag:Name"),
-				Values: []string{sgNameTag},
-			},
-			{
-				Name:   aws.String("tag-key"),
-				Values: []string{clusterTag},
-			},
+		Filters: filters,
+	})
+}
+
+// FilterSecurityGroupsByTags returns the security groups in the provided VPC that have all the provided tags.
+// At least one tag must be provided, otherwise every security group in the VPC would be returned.
+func (c *AWSClient) FilterSecurityGroupsByTags(ctx context.Context, vpcId string, tags ...v1alpha2.Tag) ([]types.SecurityGroup, error) {
+	if vpcId == "" {
+		return nil, errors.New("must specify vpc id when filtering security groups")
+	}
+
+	tagFilters := generateTagFilters(tags...)
+	if len(tagFilters) == 0 {
+		return nil, errors.New("must specify tags when filtering security groups")
+	}
+
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters: append([]types.Filter{
 			{
-				Name:   aws.String("tag:" + util.OperatorTagKey),
-				Values: []string{util.OperatorTagValue},
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcId},
 			},
-		},
-	})
+		}, tagFilters...),
+	}
+
+	var securityGroups []types.SecurityGroup
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(c.ec2Client, input)
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		securityGroups = append(securityGroups, resp.SecurityGroups...)
+	}
+
+	return securityGroups, nil
 }
 
 // FilterSecurityGroupById describes a specific security group by ID
@@ -59,3 +59,26 @@ func (c *AWSClient) AuthorizeSecurityGroupRules(ctx context.Context, ingress *ec
 
 	return rules, nil
 }
+
+// RevokeSecurityGroupRules revokes the provided ingress and egress security group rule ids from a security group
+func (c *AWSClient) RevokeSecurityGroupRules(ctx context.Context, groupId string, ingressRuleIds, egressRuleIds []string) error {
+	if len(ingressRuleIds) > 0 {
+		if _, err := c.ec2Client.RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:              aws.String(groupId),
+			SecurityGroupRuleIds: ingressRuleIds,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(egressRuleIds) > 0 {
+		if _, err := c.ec2Client.RevokeSecurityGroupEgress(ctx, &ec2.RevokeSecurityGroupEgressInput{
+			GroupId:              aws.String(groupId),
+			SecurityGroupRuleIds: egressRuleIds,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,7 +64,7 @@ func TestAWSClient_FilterSecurityGroupByDefaultTags(t *testing.T) {
 	client := NewMockedAwsClient()
 
 	for _, test := range tests {
-		_, err := client.FilterSecurityGroupByDefaultTags(context.TODO(), test.tagKey, test.nameTag)
+		_, err := client.FilterSecurityGroupByDefaultTags(context.TODO(), test.tagKey, test.nameTag, MockVpcId)
 		if test.expectErr {
 			assert.Error(t, err)
 		} else {
@@ -71,6 +73,47 @@ func TestAWSClient_FilterSecurityGroupByDefaultTags(t *testing.T) {
 	}
 }
 
+func TestAWSClient_FilterSecurityGroupsByTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		vpcId     string
+		tags      []v1alpha2.Tag
+		expectErr bool
+	}{
+		{
+			name:      "vpcId not specified",
+			tags:      []v1alpha2.Tag{{Key: "mock"}},
+			expectErr: true,
+		},
+		{
+			name:      "tags not specified",
+			vpcId:     MockVpcId,
+			expectErr: true,
+		},
+		{
+			name:      "filtered by tags",
+			vpcId:     MockVpcId,
+			tags:      []v1alpha2.Tag{{Key: "mock"}},
+			expectErr: false,
+		},
+	}
+
+	client := NewMockedAwsClient()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			securityGroups, err := client.FilterSecurityGroupsByTags(context.TODO(), test.vpcId, test.tags...)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, securityGroups, 1)
+				assert.Equal(t, MockSecurityGroupId, aws.ToString(securityGroups[0].GroupId))
+			}
+		})
+	}
+}
+
 func TestAWSClient_FilterSecurityGroupById(t *testing.T) {
 	tests := []struct {
 		groupId   string
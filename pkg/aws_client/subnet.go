@@ -20,6 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -29,6 +33,12 @@ import (
 // privateSubnetTagKey is labelled by Hive on a non-BYOVPC cluster's subnets at install time
 const privateSubnetTagKey = "kubernetes.io/role/internal-elb"
 
+// Values of .spec.vpc.subnetDiscoveryMode
+const (
+	SubnetDiscoveryModeTags        = "Tags"
+	SubnetDiscoveryModeRouteTables = "RouteTables"
+)
+
 // GetVPCId returns the VPC ID of the provided subnetIds. Returns an error if the subnets are not in the same VPC.
 func (c *AWSClient) GetVPCId(ctx context.Context, subnetIds []string) (string, error) {
 	if len(subnetIds) == 0 {
@@ -73,7 +83,7 @@ func (c *AWSClient) AutodiscoverPrivateSubnets(ctx context.Context, clusterTag s
 	}
 
 	// For BYOVPC+PrivateLink clusters, resp will contain only the private subnets.
-	// TODO: Make this work for BYOVPC non-PrivateLink clusters
+	// For BYOVPC non-PrivateLink clusters, resp also contains public subnets, see AutodiscoverPrivateSubnetsByRouteTables
 	byovpc, err := c.DescribeSubnetsByTags(ctx, append(tags, v1alpha2.Tag{Key: clusterTag})...)
 	if err != nil {
 		return nil, err
@@ -86,33 +96,270 @@ func (c *AWSClient) AutodiscoverPrivateSubnets(ctx context.Context, clusterTag s
 	return nil, fmt.Errorf("failed to find subnets with tag key: %s", clusterTag)
 }
 
-// DescribeSubnetsByTags returns a list of subnets filtered by the provided tags
-// If there is no value in the provided tag, filtering is done by tag-key only
-func (c *AWSClient) DescribeSubnetsByTags(ctx context.Context, tags ...v1alpha2.Tag) (*ec2.DescribeSubnetsOutput, error) {
-	filters := []types.Filter{}
-	for _, t := range tags {
-		// If a tag-key is empty, don't filter by it as it will exclude all subnets i.e. treat it as bad input.
-		if t.Key != "" {
+// DiscoverPrivateSubnets returns the private subnets with the clusterTag tag key, if specified, and the provided tags
+// according to the .spec.vpc.subnetDiscoveryMode mode. vpcIds, if specified, scope route table discovery to those VPCs.
+func (c *AWSClient) DiscoverPrivateSubnets(ctx context.Context, mode, clusterTag string, vpcIds []string, tags ...v1alpha2.Tag) ([]types.Subnet, error) {
+	if mode == SubnetDiscoveryModeRouteTables {
+		return c.AutodiscoverPrivateSubnetsByRouteTables(ctx, clusterTag, vpcIds, tags...)
+	}
+
+	return c.AutodiscoverPrivateSubnets(ctx, clusterTag, tags...)
+}
+
+// AutodiscoverPrivateSubnetsByRouteTables returns the subnets with the clusterTag tag key, if specified, and the
+// provided tags in the vpcIds VPCs, if specified, that are private according to their route tables, which doesn't rely
+// on private subnets being tagged as in BYOVPC non-PrivateLink clusters. A subnet is public if its explicitly associated
+// route table, or otherwise its VPC's main route table, has a default route (0.0.0.0/0 or ::/0) to an internet gateway.
+func (c *AWSClient) AutodiscoverPrivateSubnetsByRouteTables(ctx context.Context, clusterTag string, vpcIds []string, tags ...v1alpha2.Tag) ([]types.Subnet, error) {
+	// Otherwise every subnet and route table in the region would be described
+	if clusterTag == "" && len(vpcIds) == 0 {
+		return nil, errors.New("must specify a cluster tag or vpc ids when discovering private subnets by route tables")
+	}
+
+	if clusterTag != "" {
+		tags = append(slices.Clone(tags), v1alpha2.Tag{Key: clusterTag})
+	}
+
+	input := &ec2.DescribeSubnetsInput{
+		Filters: generateTagFilters(tags...),
+	}
+	if len(vpcIds) > 0 {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: vpcIds,
+		})
+	}
+
+	resp, err := c.ec2Client.DescribeSubnets(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var subnetVpcIds []string
+	for _, subnet := range resp.Subnets {
+		if subnet.VpcId != nil && !slices.Contains(subnetVpcIds, *subnet.VpcId) {
+			subnetVpcIds = append(subnetVpcIds, *subnet.VpcId)
+		}
+	}
+
+	var routeTables []types.RouteTable
+	for _, vpcId := range subnetVpcIds {
+		vpcRouteTables, err := c.FilterRouteTablesByTags(ctx, vpcId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe route tables of %s: %w", vpcId, err)
+		}
+		routeTables = append(routeTables, vpcRouteTables...)
+	}
+
+	privateSubnets := privateSubnetsByRouteTables(resp.Subnets, routeTables)
+	if len(privateSubnets) == 0 {
+		return nil, fmt.Errorf("failed to find private subnets with tag key: %s", clusterTag)
+	}
+
+	return privateSubnets, nil
+}
+
+// privateSubnetsByRouteTables returns the subnets whose route table doesn't have a default route to an internet
+// gateway. Subnets without an explicitly associated route table use the main route table of their VPC.
+func privateSubnetsByRouteTables(subnets []types.Subnet, routeTables []types.RouteTable) []types.Subnet {
+	explicit := map[string]types.RouteTable{}
+	main := map[string]types.RouteTable{}
+	for _, rt := range routeTables {
+		for _, association := range rt.Associations {
 			switch {
-			case t.Value == "":
-				// If a tag value is empty, filter by tag-key
-				filters = append(filters, types.Filter{
-					Name: aws.String("tag-key"),
-					// Values are OR-ed
-					Values: []string{t.Key},
-				})
-			default:
-				filters = append(filters, types.Filter{
-					Name:   aws.String(fmt.Sprintf("tag:%s", t.Key)),
-					Values: []string{t.Value},
-				})
+			case aws.ToBool(association.Main):
+				main[aws.ToString(rt.VpcId)] = rt
+			case association.SubnetId != nil:
+				explicit[*association.SubnetId] = rt
 			}
 		}
 	}
 
+	var privateSubnets []types.Subnet
+	for _, subnet := range subnets {
+		rt, ok := explicit[aws.ToString(subnet.SubnetId)]
+		if !ok {
+			rt, ok = main[aws.ToString(subnet.VpcId)]
+		}
+
+		if ok && hasInternetGatewayDefaultRoute(rt) {
+			continue
+		}
+
+		privateSubnets = append(privateSubnets, subnet)
+	}
+
+	return privateSubnets
+}
+
+// hasInternetGatewayDefaultRoute returns true if the route table routes 0.0.0.0/0 or ::/0 to an internet gateway
+func hasInternetGatewayDefaultRoute(rt types.RouteTable) bool {
+	return slices.ContainsFunc(rt.Routes, func(route types.Route) bool {
+		isDefault := aws.ToString(route.DestinationCidrBlock) == "0.0.0.0/0" || aws.ToString(route.DestinationIpv6CidrBlock) == "::/0"
+		return isDefault && strings.HasPrefix(aws.ToString(route.GatewayId), "igw-")
+	})
+}
+
+// DescribeSubnetsByTags returns a list of subnets filtered by the provided tags
+// If there is no value in the provided tag, filtering is done by tag-key only
+func (c *AWSClient) DescribeSubnetsByTags(ctx context.Context, tags ...v1alpha2.Tag) (*ec2.DescribeSubnetsOutput, error) {
 	input := &ec2.DescribeSubnetsInput{
-		Filters: filters,
+		Filters: generateTagFilters(tags...),
 	}
 
 	return c.ec2Client.DescribeSubnets(ctx, input)
 }
+
+// SubnetsWithoutIpv6Cidr returns the ids of the provided subnets that don't have an associated IPv6 CIDR block,
+// which is required for dualstack and IPv6 VPC Endpoints
+func (c *AWSClient) SubnetsWithoutIpv6Cidr(ctx context.Context, subnetIds []string) ([]string, error) {
+	if len(subnetIds) == 0 {
+		return nil, nil
+	}
+
+	resp, err := c.ec2Client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: subnetIds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe subnets: %w", err)
+	}
+
+	var missing []string
+	for _, subnet := range resp.Subnets {
+		hasIpv6Cidr := false
+		for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+			if association.Ipv6CidrBlockState != nil && association.Ipv6CidrBlockState.State == types.SubnetCidrBlockStateCodeAssociated {
+				hasIpv6Cidr = true
+				break
+			}
+		}
+
+		if !hasIpv6Cidr {
+			missing = append(missing, *subnet.SubnetId)
+		}
+	}
+
+	return missing, nil
+}
+
+// SubnetAvailabilityZoneIds returns the sorted, distinct zone ids (e.g. use1-az1) of the Availability Zones the
+// provided subnets are in
+func (c *AWSClient) SubnetAvailabilityZoneIds(ctx context.Context, subnetIds []string) ([]string, error) {
+	if len(subnetIds) == 0 {
+		return nil, nil
+	}
+
+	resp, err := c.ec2Client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: subnetIds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe subnets: %w", err)
+	}
+
+	var zoneIds []string
+	for _, subnet := range resp.Subnets {
+		if subnet.AvailabilityZoneId != nil && !slices.Contains(zoneIds, *subnet.AvailabilityZoneId) {
+			zoneIds = append(zoneIds, *subnet.AvailabilityZoneId)
+		}
+	}
+	slices.Sort(zoneIds)
+
+	return zoneIds, nil
+}
+
+// ValidateSubnetConfigurations returns an error if any of the static IP addresses in the subnet configurations are
+// not within the CIDR blocks of their subnets
+func (c *AWSClient) ValidateSubnetConfigurations(ctx context.Context, configs []v1alpha2.SubnetConfiguration) error {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	subnetIds := make([]string, len(configs))
+	for i, config := range configs {
+		subnetIds[i] = config.SubnetId
+	}
+
+	resp, err := c.ec2Client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: subnetIds,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe subnets: %w", err)
+	}
+
+	for _, config := range configs {
+		i := slices.IndexFunc(resp.Subnets, func(subnet types.Subnet) bool {
+			return aws.ToString(subnet.SubnetId) == config.SubnetId
+		})
+		if i < 0 {
+			return fmt.Errorf("subnet %s not found", config.SubnetId)
+		}
+
+		if err := subnetContainsAddresses(resp.Subnets[i], config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// subnetContainsAddresses returns an error if the IPv4 or IPv6 address of the subnet configuration are invalid or
+// outside the subnet's CIDR blocks
+func subnetContainsAddresses(subnet types.Subnet, config v1alpha2.SubnetConfiguration) error {
+	if config.Ipv4 != "" {
+		addr, err := netip.ParseAddr(config.Ipv4)
+		if err != nil || !addr.Is4() {
+			return fmt.Errorf("invalid IPv4 address %q for subnet %s", config.Ipv4, config.SubnetId)
+		}
+
+		prefix, err := netip.ParsePrefix(aws.ToString(subnet.CidrBlock))
+		if err != nil || !prefix.Contains(addr) {
+			return fmt.Errorf("IPv4 address %s is not within the CIDR block %s of subnet %s", config.Ipv4, aws.ToString(subnet.CidrBlock), config.SubnetId)
+		}
+	}
+
+	if config.Ipv6 != "" {
+		addr, err := netip.ParseAddr(config.Ipv6)
+		if err != nil || !addr.Is6() {
+			return fmt.Errorf("invalid IPv6 address %q for subnet %s", config.Ipv6, config.SubnetId)
+		}
+
+		var cidrs []string
+		for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+			if association.Ipv6CidrBlockState == nil || association.Ipv6CidrBlockState.State != types.SubnetCidrBlockStateCodeAssociated {
+				continue
+			}
+
+			cidrs = append(cidrs, aws.ToString(association.Ipv6CidrBlock))
+			if prefix, err := netip.ParsePrefix(aws.ToString(association.Ipv6CidrBlock)); err == nil && prefix.Contains(addr) {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("IPv6 address %s is not within the IPv6 CIDR blocks %v of subnet %s", config.Ipv6, cidrs, config.SubnetId)
+	}
+
+	return nil
+}
+
+// SubnetAvailableIpAddressCounts returns the number of free IP addresses in each of the provided subnets by subnet id
+func (c *AWSClient) SubnetAvailableIpAddressCounts(ctx context.Context, subnetIds []string) (map[string]int32, error) {
+	if len(subnetIds) == 0 {
+		return map[string]int32{}, nil
+	}
+
+	resp, err := c.ec2Client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: subnetIds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe subnets: %w", err)
+	}
+
+	counts := make(map[string]int32, len(resp.Subnets))
+	for _, subnet := range resp.Subnets {
+		if subnet.SubnetId != nil {
+			counts[*subnet.SubnetId] = aws.ToInt32(subnet.AvailableIpAddressCount)
+		}
+	}
+
+	return counts, nil
+}
@@ -19,6 +19,12 @@ package aws_client
 import (
 	"context"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAWSClient_DescribeSubnets(t *testing.T) {
@@ -53,3 +59,221 @@ func TestAWSClient_DescribeSubnets(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSClient_SubnetsWithoutIpv6Cidr(t *testing.T) {
+	tests := []struct {
+		name      string
+		subnetIds []string
+		expected  []string
+	}{
+		{
+			name:      "subnet with an IPv6 CIDR",
+			subnetIds: []string{MockPrivateSubnetId},
+			expected:  nil,
+		},
+		{
+			name:      "subnet without an IPv6 CIDR",
+			subnetIds: []string{MockPrivateSubnetId, MockPublicSubnetId},
+			expected:  []string{MockPublicSubnetId},
+		},
+		{
+			name:     "no subnets",
+			expected: nil,
+		},
+	}
+
+	client := NewMockedAwsClientWithSubnets()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := client.SubnetsWithoutIpv6Cidr(context.TODO(), test.subnetIds)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestAWSClient_SubnetAvailabilityZoneIds(t *testing.T) {
+	client := AWSClient{ec2Client: mockAvoEC2API{
+		describeSubnetsResp: &ec2.DescribeSubnetsOutput{
+			Subnets: []types.Subnet{
+				{SubnetId: aws.String("subnet-1"), AvailabilityZoneId: aws.String("use1-az2")},
+				{SubnetId: aws.String("subnet-2"), AvailabilityZoneId: aws.String("use1-az1")},
+				{SubnetId: aws.String("subnet-3"), AvailabilityZoneId: aws.String("use1-az2")},
+			},
+		},
+	}}
+
+	actual, err := client.SubnetAvailabilityZoneIds(context.TODO(), []string{"subnet-1", "subnet-2", "subnet-3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"use1-az1", "use1-az2"}, actual)
+}
+
+func TestSubnetContainsAddresses(t *testing.T) {
+	subnet := types.Subnet{
+		SubnetId:  aws.String("subnet-1"),
+		CidrBlock: aws.String("10.0.1.0/24"),
+		Ipv6CidrBlockAssociationSet: []types.SubnetIpv6CidrBlockAssociation{
+			{
+				Ipv6CidrBlock: aws.String("2600:1f18::/64"),
+				Ipv6CidrBlockState: &types.SubnetCidrBlockState{
+					State: types.SubnetCidrBlockStateCodeAssociated,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		config    v1alpha2.SubnetConfiguration
+		expectErr bool
+	}{
+		{
+			name:   "no addresses",
+			config: v1alpha2.SubnetConfiguration{SubnetId: "subnet-1"},
+		},
+		{
+			name:   "addresses within CIDR blocks",
+			config: v1alpha2.SubnetConfiguration{SubnetId: "subnet-1", Ipv4: "10.0.1.10", Ipv6: "2600:1f18::10"},
+		},
+		{
+			name:      "IPv4 address outside CIDR block",
+			config:    v1alpha2.SubnetConfiguration{SubnetId: "subnet-1", Ipv4: "10.0.2.10"},
+			expectErr: true,
+		},
+		{
+			name:      "IPv6 address outside CIDR block",
+			config:    v1alpha2.SubnetConfiguration{SubnetId: "subnet-1", Ipv6: "2600:1f19::10"},
+			expectErr: true,
+		},
+		{
+			name:      "IPv6 address as IPv4",
+			config:    v1alpha2.SubnetConfiguration{SubnetId: "subnet-1", Ipv4: "2600:1f18::10"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid address",
+			config:    v1alpha2.SubnetConfiguration{SubnetId: "subnet-1", Ipv4: "10.0.1"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := subnetContainsAddresses(subnet, test.config)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAWSClient_AutodiscoverPrivateSubnetsByRouteTables(t *testing.T) {
+	subnets := []types.Subnet{
+		// Explicitly associated with a public route table
+		{SubnetId: aws.String("subnet-public"), VpcId: aws.String("vpc-1")},
+		// Explicitly associated with a private route table
+		{SubnetId: aws.String("subnet-private"), VpcId: aws.String("vpc-1")},
+		// Implicitly associated with the main route table
+		{SubnetId: aws.String("subnet-main"), VpcId: aws.String("vpc-1")},
+	}
+
+	tests := []struct {
+		name        string
+		clusterTag  string
+		vpcIds      []string
+		routeTables []types.RouteTable
+		expected    []string
+		expectErr   bool
+	}{
+		{
+			name:       "private main route table",
+			clusterTag: MockClusterTag,
+			routeTables: []types.RouteTable{
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []types.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+					Routes:       []types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}},
+				},
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []types.RouteTableAssociation{{SubnetId: aws.String("subnet-private")}},
+					Routes:       []types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1")}},
+				},
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []types.RouteTableAssociation{{Main: aws.Bool(true)}},
+					Routes:       []types.Route{{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")}},
+				},
+			},
+			expected: []string{"subnet-private", "subnet-main"},
+		},
+		{
+			name:       "public main route table",
+			clusterTag: MockClusterTag,
+			routeTables: []types.RouteTable{
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []types.RouteTableAssociation{{SubnetId: aws.String("subnet-private")}},
+				},
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []types.RouteTableAssociation{{Main: aws.Bool(true)}},
+					Routes:       []types.Route{{DestinationIpv6CidrBlock: aws.String("::/0"), GatewayId: aws.String("igw-1")}},
+				},
+			},
+			expected: []string{"subnet-private"},
+		},
+		{
+			name:   "scoped to vpc ids without a cluster tag",
+			vpcIds: []string{"vpc-1"},
+			routeTables: []types.RouteTable{
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []types.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+					Routes:       []types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}},
+				},
+			},
+			expected: []string{"subnet-private", "subnet-main"},
+		},
+		{
+			name:      "no cluster tag or vpc ids",
+			expectErr: true,
+		},
+		{
+			name:       "only public subnets",
+			clusterTag: MockClusterTag,
+			routeTables: []types.RouteTable{
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []types.RouteTableAssociation{{Main: aws.Bool(true)}},
+					Routes:       []types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := AWSClient{ec2Client: mockAvoEC2API{
+				describeSubnetsResp:     &ec2.DescribeSubnetsOutput{Subnets: subnets},
+				describeRouteTablesResp: &ec2.DescribeRouteTablesOutput{RouteTables: test.routeTables},
+			}}
+
+			actual, err := client.AutodiscoverPrivateSubnetsByRouteTables(context.TODO(), test.clusterTag, test.vpcIds)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				var actualIds []string
+				for _, subnet := range actual {
+					actualIds = append(actualIds, *subnet.SubnetId)
+				}
+				assert.Equal(t, test.expected, actualIds)
+			}
+		})
+	}
+}
@@ -18,8 +18,13 @@ package aws_client
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
 )
 
 // CreateTags creates tags in an idempotent fashion
@@ -36,3 +41,30 @@ func (c *AWSClient) ListTagsForResource(ctx context.Context, params *route53.Lis
 func (c *AWSClient) ChangeTagsForResource(ctx context.Context, params *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error) {
 	return c.route53Client.ChangeTagsForResource(ctx, params)
 }
+
+// generateTagFilters returns EC2 API filters matching all the provided tags.
+// If there is no value in the provided tag, filtering is done by tag-key only
+func generateTagFilters(tags ...v1alpha2.Tag) []types.Filter {
+	filters := []types.Filter{}
+	for _, t := range tags {
+		// If a tag-key is empty, don't filter by it as it will exclude all resources i.e. treat it as bad input.
+		if t.Key != "" {
+			switch {
+			case t.Value == "":
+				// If a tag value is empty, filter by tag-key
+				filters = append(filters, types.Filter{
+					Name: aws.String("tag-key"),
+					// Values are OR-ed
+					Values: []string{t.Key},
+				})
+			default:
+				filters = append(filters, types.Filter{
+					Name:   aws.String(fmt.Sprintf("tag:%s", t.Key)),
+					Values: []string{t.Value},
+				})
+			}
+		}
+	}
+
+	return filters
+}
@@ -20,7 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
+	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -30,12 +30,12 @@ import (
 	"github.com/openshift/aws-vpce-operator/pkg/util"
 )
 
-// SelectVPCForVPCEndpoint uses a "least connection" strategy to place a VPC Endpoint in the provided VPC ID with the
-// fewest existing VPC Endpoints in it to balance out quota usage.
-// https://docs.aws.amazon.com/vpc/latest/userguide/amazon-vpc-limits.html#vpc-limits-endpoints
-func (c *AWSClient) SelectVPCForVPCEndpoint(ctx context.Context, ids ...string) (string, error) {
+// CountVPCEndpointsPerVPC returns the number of existing VPC Endpoints in each of the provided VPC IDs that count
+// towards the per-VPC quota of Interface and Gateway Load Balancer VPC Endpoints, i.e. excluding Gateway VPC
+// Endpoints and VPC Endpoints that are being deleted
+func (c *AWSClient) CountVPCEndpointsPerVPC(ctx context.Context, ids ...string) (map[string]int, error) {
 	if len(ids) == 0 {
-		return "", errors.New("must specify vpc id when counting VPC Endpoints per VPC")
+		return nil, errors.New("must specify vpc id when counting VPC Endpoints per VPC")
 	}
 
 	input := &ec2.DescribeVpcEndpointsInput{
@@ -47,8 +47,6 @@ func (c *AWSClient) SelectVPCForVPCEndpoint(ctx context.Context, ids ...string)
 		},
 	}
 
-	minVpcId := ""
-	minVpceConsumed := math.MaxInt
 	vpcePerVpc := map[string]int{}
 	for _, id := range ids {
 		vpcePerVpc[id] = 0
@@ -58,26 +56,26 @@ func (c *AWSClient) SelectVPCForVPCEndpoint(ctx context.Context, ids ...string)
 	for paginator.HasMorePages() {
 		resp, err := paginator.NextPage(ctx)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		for _, vpce := range resp.VpcEndpoints {
-			vpcePerVpc[*vpce.VpcId]++
-		}
-	}
+			if vpce.VpcEndpointType == types.VpcEndpointTypeGateway || vpce.State == "deleting" || vpce.State == "deleted" {
+				continue
+			}
 
-	for vpcId, vpceCount := range vpcePerVpc {
-		if vpceCount < minVpceConsumed {
-			minVpceConsumed = vpceCount
-			minVpcId = vpcId
+			vpcePerVpc[*vpce.VpcId]++
 		}
 	}
 
-	if minVpcId == "" {
-		return "", errors.New("unexpectedly did not select a VPC for the VPC Endpoint")
-	}
+	return vpcePerVpc, nil
+}
 
-	return minVpcId, nil
+// IsVpcEndpointLimitExceeded returns true if err is AWS rejecting a new VPC Endpoint because its VPC has reached the
+// quota of VPC Endpoints
+func IsVpcEndpointLimitExceeded(err error) bool {
+	var ae smithy.APIError
+	return errors.As(err, &ae) && ae.ErrorCode() == "VpcEndpointLimitExceeded"
 }
 
 // FilterVpcIdsByTags tags in a list of tags and returns a list of AWS VPC Ids that have all of the provided tags
@@ -143,34 +141,46 @@ func (c *AWSClient) DescribeSingleVPCEndpointById(ctx context.Context, id string
 	return resp, err
 }
 
-// FilterVPCEndpointByDefaultTags returns information about a VPC endpoint with the default expected tags.
-func (c *AWSClient) FilterVPCEndpointByDefaultTags(ctx context.Context, clusterTag, vpceNameTag string) (*ec2.DescribeVpcEndpointsOutput, error) {
+// FilterVPCEndpointByDefaultTags returns information about a VPC endpoint with the default expected tags,
+// only in the provided VPC if vpcId is specified.
+func (c *AWSClient) FilterVPCEndpointByDefaultTags(ctx context.Context, clusterTag, vpceNameTag, vpcId string) (*ec2.DescribeVpcEndpointsOutput, error) {
 	if clusterTag == "" {
 		return &ec2.DescribeVpcEndpointsOutput{}, nil
 	}
 
-	return c.ec2Client.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
-		Filters: []types.Filter{
-			{
-				Name:   aws.String("tag:Name"),
-				Values: []string{vpceNameTag},
-			},
-			{
-				Name:   aws.String("tag-key"),
-				Values: []string{clusterTag},
-			},
-			{
-				Name:   aws.String("tag:" + util.OperatorTagKey),
-				Values: []string{util.OperatorTagValue},
-			},
+	filters := []types.Filter{
+		{
+			Name:   aws.String("tag:Name"),
+			Values: []string{vpceNameTag},
+		},
+		{
+			Name:   aws.String("tag-key"),
+			Values: []string{clusterTag},
 		},
+		{
+			Name:   aws.String("tag:" + util.OperatorTagKey),
+			Values: []string{util.OperatorTagValue},
+		},
+	}
+
+	// VPC Endpoints in other VPCs share the same tags while migrating to another VPC
+	if vpcId != "" {
+		filters = append(filters, types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcId},
+		})
+	}
+
+	return c.ec2Client.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
+		Filters: filters,
 	})
 }
 
-// CreateDefaultInterfaceVPCEndpoint creates an interface VPC endpoint with
+// CreateDefaultVPCEndpoint creates a VPC endpoint of the specified type with
 // the default (open to all) VPC Endpoint policy. It attaches no security groups
-// nor associates the VPC Endpoint with any subnets.
-func (c *AWSClient) CreateDefaultInterfaceVPCEndpoint(ctx context.Context, name, vpcId, serviceName, tagKey string) (*ec2.CreateVpcEndpointOutput, error) {
+// nor associates the VPC Endpoint with any subnets or route tables.
+// optFns can be used to further customize the request, e.g. to enable private DNS.
+func (c *AWSClient) CreateDefaultVPCEndpoint(ctx context.Context, name, vpcId, serviceName, tagKey string, endpointType types.VpcEndpointType, optFns ...func(*ec2.CreateVpcEndpointInput)) (*ec2.CreateVpcEndpointOutput, error) {
 	tags, err := util.GenerateAwsTags(name, tagKey)
 	if err != nil {
 		return nil, err
@@ -181,7 +191,7 @@ func (c *AWSClient) CreateDefaultInterfaceVPCEndpoint(ctx context.Context, name,
 		// ClientToken:     "token",
 		VpcId:           &vpcId,
 		ServiceName:     &serviceName,
-		VpcEndpointType: types.VpcEndpointTypeInterface,
+		VpcEndpointType: endpointType,
 		TagSpecifications: []types.TagSpecification{
 			{
 				ResourceType: types.ResourceTypeVpcEndpoint,
@@ -190,6 +200,10 @@ func (c *AWSClient) CreateDefaultInterfaceVPCEndpoint(ctx context.Context, name,
 		},
 	}
 
+	for _, fn := range optFns {
+		fn(input)
+	}
+
 	return c.ec2Client.CreateVpcEndpoint(ctx, input)
 }
 
@@ -206,3 +220,64 @@ func (c *AWSClient) DeleteVPCEndpoint(ctx context.Context, id string) (*ec2.Dele
 func (c *AWSClient) ModifyVpcEndpoint(ctx context.Context, input *ec2.ModifyVpcEndpointInput) (*ec2.ModifyVpcEndpointOutput, error) {
 	return c.ec2Client.ModifyVpcEndpoint(ctx, input)
 }
+
+// FilterNetworkInterfacesByIds returns the provided network interfaces that still exist, e.g. to wait for the network
+// interfaces of subnets removed from a VPC Endpoint to be deleted. Unlike describing them by id, it doesn't fail if
+// some of them no longer exist.
+func (c *AWSClient) FilterNetworkInterfacesByIds(ctx context.Context, networkInterfaceIds []string) ([]types.NetworkInterface, error) {
+	if len(networkInterfaceIds) == 0 {
+		return nil, nil
+	}
+
+	resp, err := c.ec2Client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("network-interface-id"),
+				Values: networkInterfaceIds,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe network interfaces: %w", err)
+	}
+
+	return resp.NetworkInterfaces, nil
+}
+
+// NetworkInterfaceAddressesBySubnet returns the private IPv4 and IPv6 addresses of the provided network interfaces,
+// e.g. a VPC Endpoint's, by the id of the subnet they are in
+func (c *AWSClient) NetworkInterfaceAddressesBySubnet(ctx context.Context, networkInterfaceIds []string) (map[string][]string, error) {
+	if len(networkInterfaceIds) == 0 {
+		return map[string][]string{}, nil
+	}
+
+	resp, err := c.ec2Client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: networkInterfaceIds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe network interfaces: %w", err)
+	}
+
+	addresses := map[string][]string{}
+	for _, eni := range resp.NetworkInterfaces {
+		if eni.SubnetId == nil {
+			continue
+		}
+
+		for _, privateIp := range eni.PrivateIpAddresses {
+			if privateIp.PrivateIpAddress != nil {
+				addresses[*eni.SubnetId] = append(addresses[*eni.SubnetId], *privateIp.PrivateIpAddress)
+			}
+		}
+		if eni.PrivateIpAddress != nil && !slices.Contains(addresses[*eni.SubnetId], *eni.PrivateIpAddress) {
+			addresses[*eni.SubnetId] = append(addresses[*eni.SubnetId], *eni.PrivateIpAddress)
+		}
+		for _, ipv6 := range eni.Ipv6Addresses {
+			if ipv6.Ipv6Address != nil {
+				addresses[*eni.SubnetId] = append(addresses[*eni.SubnetId], *ipv6.Ipv6Address)
+			}
+		}
+	}
+
+	return addresses, nil
+}
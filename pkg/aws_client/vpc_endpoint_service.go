@@ -26,10 +26,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
-// GetVpcEndpointServiceAZs returns a slice of strings indicating which AZs the specified VPC Endpoint Service supports
-func (c *AWSClient) GetVpcEndpointServiceAZs(ctx context.Context, serviceName string) ([]string, error) {
+// DescribeVpcEndpointService returns the details of a single VPC Endpoint Service with the specified name
+func (c *AWSClient) DescribeVpcEndpointService(ctx context.Context, serviceName string) (*types.ServiceDetail, error) {
 	if serviceName == "" {
-		return nil, errors.New("GetVpcEndpointServiceAZs: serviceName must be specified")
+		return nil, errors.New("DescribeVpcEndpointService: serviceName must be specified")
 	}
 
 	input := &ec2.DescribeVpcEndpointServicesInput{
@@ -45,7 +45,58 @@ func (c *AWSClient) GetVpcEndpointServiceAZs(ctx context.Context, serviceName st
 		return nil, fmt.Errorf("expected one VPC Endpoint Service with name %s, got %d", serviceName, len(resp.ServiceDetails))
 	}
 
-	return resp.ServiceDetails[0].AvailabilityZones, nil
+	return &resp.ServiceDetails[0], nil
+}
+
+// GetVpcEndpointServiceAZs returns a slice of strings indicating which AZs the specified VPC Endpoint Service supports
+func (c *AWSClient) GetVpcEndpointServiceAZs(ctx context.Context, serviceName string) ([]string, error) {
+	service, err := c.DescribeVpcEndpointService(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.AvailabilityZones, nil
+}
+
+// GetVpcEndpointServiceAZIds returns the zone ids (e.g. use1-az1) of the AZs the specified VPC Endpoint Service
+// supports, mapped to their AZ names (e.g. us-east-1a). AZ names are mapped to different physical zones in each AWS
+// account, so zone ids should be used to compare them with the AZs of subnets.
+func (c *AWSClient) GetVpcEndpointServiceAZIds(ctx context.Context, serviceName string) (map[string]string, error) {
+	azs, err := c.GetVpcEndpointServiceAZs(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AvailabilityZoneIds(ctx, azs...)
+}
+
+// AvailabilityZoneIds resolves AZ names to their zone ids in the current AWS account, returning a map of zone id to
+// AZ name
+func (c *AWSClient) AvailabilityZoneIds(ctx context.Context, names ...string) (map[string]string, error) {
+	if len(names) == 0 {
+		return map[string]string{}, nil
+	}
+
+	resp, err := c.ec2Client.DescribeAvailabilityZones(ctx, &ec2.DescribeAvailabilityZonesInput{
+		ZoneNames: names,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zoneIds := make(map[string]string, len(resp.AvailabilityZones))
+	for _, az := range resp.AvailabilityZones {
+		if az.ZoneId == nil || az.ZoneName == nil {
+			continue
+		}
+		zoneIds[*az.ZoneId] = *az.ZoneName
+	}
+
+	if len(zoneIds) != len(names) {
+		return nil, fmt.Errorf("expected zone ids for %d Availability Zones %v, got %d", len(names), names, len(zoneIds))
+	}
+
+	return zoneIds, nil
 }
 
 // GetVpcEndpointConnectionsPendingAcceptance returns information about a VPC endpoint with a given id.
@@ -23,8 +23,42 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
 )
 
+func TestAWSClient_DescribeVpcEndpointService(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceName string
+		expectErr   bool
+	}{
+		{
+			name:      "serviceName not specified",
+			expectErr: true,
+		},
+		{
+			name:        "service found",
+			serviceName: MockVpcEndpointServiceName,
+			expectErr:   false,
+		},
+	}
+
+	client := NewMockedAwsClient()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, err := client.DescribeVpcEndpointService(context.TODO(), test.serviceName)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, MockPrivateDnsName, aws.ToString(service.PrivateDnsName))
+				assert.Equal(t, types.DnsNameStateVerified, service.PrivateDnsNameVerificationState)
+			}
+		})
+	}
+}
+
 func TestAWSClient_GetVpcEndpointServiceAZs(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -76,6 +110,59 @@ func TestAWSClient_GetVpcEndpointServiceAZs(t *testing.T) {
 	}
 }
 
+func TestAWSClient_GetVpcEndpointServiceAZIds(t *testing.T) {
+	tests := []struct {
+		name      string
+		azResp    *ec2.DescribeAvailabilityZonesOutput
+		expected  map[string]string
+		expectErr bool
+	}{
+		{
+			name: "zone ids resolved",
+			azResp: &ec2.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: []types.AvailabilityZone{
+					{ZoneId: aws.String("use1-az4"), ZoneName: aws.String("us-east-1a")},
+					{ZoneId: aws.String("use1-az6"), ZoneName: aws.String("us-east-1b")},
+				},
+			},
+			expected: map[string]string{"use1-az4": "us-east-1a", "use1-az6": "us-east-1b"},
+		},
+		{
+			name: "zone id missing",
+			azResp: &ec2.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: []types.AvailabilityZone{
+					{ZoneId: aws.String("use1-az4"), ZoneName: aws.String("us-east-1a")},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := AWSClient{ec2Client: mockAvoEC2API{
+				describeVpcEndpointServicesResp: &ec2.DescribeVpcEndpointServicesOutput{
+					ServiceDetails: []types.ServiceDetail{
+						{
+							AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+							ServiceName:       aws.String("mock"),
+						},
+					},
+				},
+				describeAvailabilityZonesResp: test.azResp,
+			}}
+
+			actual, err := client.GetVpcEndpointServiceAZIds(context.TODO(), "mock")
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
 func TestVpcEndpointAcceptanceAWSClient_AcceptVpcEndpointConnections(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -18,64 +18,102 @@ package aws_client
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 	"github.com/openshift/aws-vpce-operator/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestAWSClient_SelectVPCForVPCEndpoint(t *testing.T) {
+func TestAWSClient_CountVPCEndpointsPerVPC(t *testing.T) {
 	tests := []struct {
-		name       string
-		ids        []string
-		resp       *ec2.DescribeVpcEndpointsOutput
-		expectedId string
-		expectErr  bool
+		name      string
+		ids       []string
+		resp      *ec2.DescribeVpcEndpointsOutput
+		expected  map[string]int
+		expectErr bool
 	}{
 		{
 			name:      "No ids",
 			expectErr: true,
 		},
 		{
-			name:       "No VPC Endpoints",
-			ids:        []string{"vpc-01"},
-			resp:       &ec2.DescribeVpcEndpointsOutput{},
-			expectedId: "vpc-01",
-			expectErr:  false,
-		},
-		{
-			name: "vpc-02 is more empty",
-			ids:  []string{"vpc-01", "vpc-02"},
+			name: "Counts VPC Endpoints",
+			ids:  []string{"vpc-01", "vpc-02", "vpc-03"},
 			resp: &ec2.DescribeVpcEndpointsOutput{
 				VpcEndpoints: []types.VpcEndpoint{
-					{VpcId: aws.String("vpc-01")},
 					{VpcId: aws.String("vpc-01")},
 					{VpcId: aws.String("vpc-01")},
 					{VpcId: aws.String("vpc-02")},
 				},
 			},
-			expectedId: "vpc-02",
-			expectErr:  false,
+			expected: map[string]int{"vpc-01": 2, "vpc-02": 1, "vpc-03": 0},
+		},
+		{
+			name: "Excludes Gateway and deleting VPC Endpoints",
+			ids:  []string{"vpc-01"},
+			resp: &ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []types.VpcEndpoint{
+					{VpcId: aws.String("vpc-01"), VpcEndpointType: types.VpcEndpointTypeInterface},
+					{VpcId: aws.String("vpc-01"), VpcEndpointType: types.VpcEndpointTypeGateway},
+					{VpcId: aws.String("vpc-01"), State: "deleting"},
+					{VpcId: aws.String("vpc-01"), State: "deleted"},
+				},
+			},
+			expected: map[string]int{"vpc-01": 1},
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			client := AWSClient{ec2Client: mockAvoEC2API{describeVpcEndpointResp: test.resp}}
-			actualId, err := client.SelectVPCForVPCEndpoint(context.TODO(), test.ids...)
-			if err != nil {
+			actual, err := client.CountVPCEndpointsPerVPC(context.TODO(), test.ids...)
+			if test.expectErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Equalf(t, test.expectedId, actualId, "expected %s, got %s", test.expectedId, actualId)
+				assert.Equal(t, test.expected, actual)
 			}
 		})
 	}
 }
 
+func TestIsVpcEndpointLimitExceeded(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "other error",
+			err:  errors.New("VpcEndpointLimitExceeded"),
+		},
+		{
+			name: "other API error",
+			err:  &smithy.GenericAPIError{Code: "InvalidVpcId.NotFound"},
+		},
+		{
+			name:     "VpcEndpointLimitExceeded",
+			err:      fmt.Errorf("failed to create vpc endpoint: %w", &smithy.GenericAPIError{Code: "VpcEndpointLimitExceeded"}),
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsVpcEndpointLimitExceeded(test.err))
+		})
+	}
+}
+
 func TestAWSClient_DescribeSingleVPCEndpointById(t *testing.T) {
 	client := NewMockedAwsClient()
 
@@ -87,14 +125,14 @@ func TestAWSClient_DescribeSingleVPCEndpointById(t *testing.T) {
 func TestAWSClient_FilterVPCEndpointByDefaultTags(t *testing.T) {
 	client := NewMockedAwsClient()
 
-	_, err := client.FilterVPCEndpointByDefaultTags(context.TODO(), MockClusterTag, MockClusterNameTag)
+	_, err := client.FilterVPCEndpointByDefaultTags(context.TODO(), MockClusterTag, MockClusterNameTag, MockVpcId)
 	assert.NoError(t, err)
 }
 
 func TestCreateDeleteVPCEndpoint(t *testing.T) {
 	client := NewMockedAwsClient()
 
-	resp, err := client.CreateDefaultInterfaceVPCEndpoint(context.TODO(), "name", MockVpcId, MockVpcEndpointServiceName, MockClusterTag)
+	resp, err := client.CreateDefaultVPCEndpoint(context.TODO(), "name", MockVpcId, MockVpcEndpointServiceName, MockClusterTag, types.VpcEndpointTypeInterface)
 	assert.NoError(t, err)
 
 	_, err = client.DeleteVPCEndpoint(context.TODO(), *resp.VpcEndpoint.VpcEndpointId)
@@ -35,6 +35,9 @@ const (
 	MockInfrastructureName = "mock-12345"
 	MockVpcEndpointId      = "vpce-12345"
 	MockVpcEndpointDnsName = "vpce-12345.amazonaws.com"
+
+	MockVpcEndpointHostedZoneId = "Z1HUB23UULQXV"
+	MockVpcEndpointZonalDnsName = "vpce-12345-us-gov-west-1a.amazonaws.com"
 )
 
 type MockKubeClient struct {